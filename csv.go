@@ -0,0 +1,21 @@
+package orderedmap
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// WriteCSV writes entries of `om` to `w` as a two-column CSV (key, value) in keys insertion order.
+//
+// It is a function rather than a method, because it is only applicable to maps with string keys and values.
+func WriteCSV[K, V ~string](om *OrderedMap[K, V], w io.Writer) error {
+	cw := csv.NewWriter(w)
+	for curr := om.items.head; curr != nil; curr = curr.next {
+		if err := cw.Write([]string{string(curr.value), string(om.data[curr.value].value)}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
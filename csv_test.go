@@ -0,0 +1,48 @@
+package orderedmap
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestWriteCSV(t *testing.T) {
+	om := New[string, string]()
+	om.Set("name", "orderedmap")
+	om.Set("description", "ordered, generic")
+	om.Set("quote", `say "hi"`)
+	om.Set("multiline", "a\nb")
+	om.Set("empty", "")
+
+	const expected = "name,orderedmap\n" +
+		"description,\"ordered, generic\"\n" +
+		"quote,\"say \"\"hi\"\"\"\n" +
+		"multiline,\"a\nb\"\n" +
+		"empty,\n"
+
+	var buf bytes.Buffer
+	if err := WriteCSV(om, &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if s := buf.String(); s != expected {
+		t.Fatalf("wanted: %q, got: %q", expected, s)
+	}
+
+	buf.Reset()
+	if err := WriteCSV(New[string, string](), &buf); err != nil || buf.Len() != 0 {
+		t.Fatalf("empty map should produce empty output, got: %q, %v", buf.String(), err)
+	}
+
+	if err := WriteCSV(om, failingWriter{}); !errors.Is(err, errWrite) {
+		t.Fatalf("wanted: %v, got: %v", errWrite, err)
+	}
+}
+
+var errWrite = errors.New("write failed")
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errWrite
+}
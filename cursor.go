@@ -0,0 +1,58 @@
+package orderedmap
+
+// Cursor allows to iterate over key->value pairs of a map in keys insertion order.
+//
+// Unlike the function returned by Iterator, a cursor can skip entries without visiting them
+// and can be reused for multiple passes over a map via Reset, which avoids allocations in hot loops.
+//
+// NOTE: if a map is modified when iteration is in progress,
+// the result of a subsequent call to cursor methods is undefined.
+type Cursor[K comparable, V any] struct {
+	om   *OrderedMap[K, V]
+	curr *node[K]
+}
+
+// NewCursor returns a cursor positioned at the beginning of a map.
+//
+// Example:
+//
+//	c := om.NewCursor()
+//	for k, v, ok := c.Next(); ok; k, v, ok = c.Next() {
+//	  fmt.Printf("key: %v, value: %v", k, v)
+//	}
+func (om *OrderedMap[K, V]) NewCursor() *Cursor[K, V] {
+	return &Cursor[K, V]{om: om, curr: om.items.head}
+}
+
+// Next returns the current entry and advances a cursor to the next one.
+//
+// Returns:
+//   - (key, value, true) if there are unvisited entries left;
+//   - (<zero>, <zero>, false) otherwise, where <zero> represents a default value for types K and V.
+func (c *Cursor[K, V]) Next() (K, V, bool) {
+	key, val, ok := c.om.entry(c.curr)
+	if ok {
+		c.curr = c.curr.next
+	}
+
+	return key, val, ok
+}
+
+// Reset re-positions a cursor at the beginning of a map, so it can be reused for another pass.
+// It is safe to call Reset after a map was modified: subsequent iteration reflects the current contents of a map.
+func (c *Cursor[K, V]) Reset() {
+	c.curr = c.om.items.head
+}
+
+// Seek advances a cursor by `n` entries without visiting them.
+//
+// Returns:
+//   - number of entries actually skipped, which is less than `n` if the end of a map was reached.
+func (c *Cursor[K, V]) Seek(n int) int {
+	skipped := 0
+	for ; c.curr != nil && skipped < n; skipped++ {
+		c.curr = c.curr.next
+	}
+
+	return skipped
+}
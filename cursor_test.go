@@ -0,0 +1,155 @@
+package orderedmap
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCursor(t *testing.T) {
+	keys := []string{"a", "b", "c", "d", "e", "f"}
+
+	om := New[string, int]()
+	for i, k := range keys {
+		om.Set(k, i)
+	}
+
+	c := om.NewCursor()
+
+	if n := c.Seek(0); n != 0 {
+		t.Fatalf("wanted: %d, got: %d", 0, n)
+	}
+
+	if k, v, ok := c.Next(); !ok || k != "a" || v != 0 {
+		t.Fatalf("next, wanted: (%q, %d), got: (%q, %d)", "a", 0, k, v)
+	}
+
+	if n := c.Seek(2); n != 2 {
+		t.Fatalf("wanted: %d, got: %d", 2, n)
+	}
+
+	var actual []string
+	for k, v, ok := c.Next(); ok; k, v, ok = c.Next() {
+		if k != keys[v] {
+			t.Fatalf("wanted: %q, got: %q", keys[v], k)
+		}
+
+		actual = append(actual, k)
+		c.Seek(1)
+	}
+
+	if !slices.Equal(actual, []string{"d", "f"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"d", "f"}, actual)
+	}
+
+	c = om.NewCursor()
+	c.Next()
+
+	if n := c.Seek(10); n != len(keys)-1 {
+		t.Fatalf("wanted: %d, got: %d", len(keys)-1, n)
+	}
+
+	if _, _, ok := c.Next(); ok {
+		t.Fatalf("cursor should not yield anything after the end of a map")
+	}
+
+	if _, _, ok := New[string, int]().NewCursor().Next(); ok {
+		t.Fatalf("cursor over empty map should not yield anything")
+	}
+}
+
+func TestCursorReset(t *testing.T) {
+	om := New[string, int]()
+	om.Set("a", 1)
+	om.Set("b", 2)
+	om.Set("c", 3)
+
+	collect := func(c *Cursor[string, int]) []string {
+		var keys []string
+		for k, _, ok := c.Next(); ok; k, _, ok = c.Next() {
+			keys = append(keys, k)
+		}
+
+		return keys
+	}
+
+	c := om.NewCursor()
+
+	if keys := collect(c); !slices.Equal(keys, []string{"a", "b", "c"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"a", "b", "c"}, keys)
+	}
+
+	if _, _, ok := c.Next(); ok {
+		t.Fatalf("exhausted cursor should not return entries")
+	}
+
+	c.Reset()
+
+	if keys := collect(c); !slices.Equal(keys, []string{"a", "b", "c"}) {
+		t.Fatalf("after reset, wanted: %q, got: %q", []string{"a", "b", "c"}, keys)
+	}
+
+	c.Reset()
+	c.Seek(2)
+	c.Reset()
+
+	if k, v, ok := c.Next(); !ok || k != "a" || v != 1 {
+		t.Fatalf("next, wanted: (%q, %d), got: (%q, %d)", "a", 1, k, v)
+	}
+
+	om.Delete("a")
+	om.Set("d", 4)
+	c.Reset()
+
+	if keys := collect(c); !slices.Equal(keys, []string{"b", "c", "d"}) {
+		t.Fatalf("after modification, wanted: %q, got: %q", []string{"b", "c", "d"}, keys)
+	}
+}
+
+// sink is used by BenchmarkIterator/Escaping to model callers which store an iterator or pass it around,
+// in which case the closure returned by Iterator is allocated on the heap.
+var sink func() (int, int, bool)
+
+func BenchmarkIterator(b *testing.B) {
+	om := New[int, int]()
+	for i := 0; i < 16; i++ {
+		om.Set(i, i)
+	}
+
+	b.Run("Local", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			it := om.Iterator()
+			for _, _, ok := it(); ok; _, _, ok = it() {
+			}
+		}
+	})
+
+	b.Run("Escaping", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			sink = om.Iterator()
+			for _, _, ok := sink(); ok; _, _, ok = sink() {
+			}
+		}
+	})
+}
+
+func BenchmarkCursorReset(b *testing.B) {
+	om := New[int, int]()
+	for i := 0; i < 16; i++ {
+		om.Set(i, i)
+	}
+
+	c := om.NewCursor()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		c.Reset()
+		for _, _, ok := c.Next(); ok; _, _, ok = c.Next() {
+		}
+	}
+}
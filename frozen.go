@@ -0,0 +1,67 @@
+package orderedmap
+
+// FrozenOrderedMap is an immutable snapshot of OrderedMap.
+//
+// It provides read-only access to entries of a map captured at the moment of Freeze call,
+// subsequent modifications of the source map are not visible through the snapshot.
+// Since a snapshot cannot be modified, it is safe to use it concurrently from multiple goroutines.
+type FrozenOrderedMap[K comparable, V any] struct {
+	keys   []K
+	values []V
+	index  map[K]int
+}
+
+// Freeze creates an immutable snapshot of a map and returns a pointer to it.
+//
+// NOTE: values are copied by assignment, so values of reference types still share underlying data with the source map.
+func (om *OrderedMap[K, V]) Freeze() *FrozenOrderedMap[K, V] {
+	fm := &FrozenOrderedMap[K, V]{
+		keys:   om.Keys(),
+		values: om.Values(),
+		index:  make(map[K]int, om.Len()),
+	}
+
+	for i, key := range fm.keys {
+		fm.index[key] = i
+	}
+
+	return fm
+}
+
+// Get retrieves a value corresponding to `key`. See OrderedMap.Get for details.
+func (fm *FrozenOrderedMap[K, V]) Get(key K) (V, bool) {
+	if i, ok := fm.index[key]; ok {
+		return fm.values[i], true
+	}
+
+	var def V
+	return def, false
+}
+
+// Len returns total number of elements in a snapshot.
+func (fm *FrozenOrderedMap[K, V]) Len() int {
+	return len(fm.keys)
+}
+
+// Keys returns a slice of all keys of a snapshot in keys insertion order.
+//
+// The returned slice is a copy, so it can be safely modified by the caller.
+func (fm *FrozenOrderedMap[K, V]) Keys() []K {
+	return append(make([]K, 0, len(fm.keys)), fm.keys...)
+}
+
+// Iterator returns a function which can be used to iterate over key->value pairs of a snapshot
+// in keys insertion order. See OrderedMap.Iterator for details.
+func (fm *FrozenOrderedMap[K, V]) Iterator() func() (K, V, bool) {
+	i := 0
+	return func() (K, V, bool) {
+		if i >= len(fm.keys) {
+			var key K
+			var val V
+			return key, val, false
+		}
+
+		i++
+		return fm.keys[i-1], fm.values[i-1], true
+	}
+}
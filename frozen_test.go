@@ -0,0 +1,63 @@
+package orderedmap
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestOrderedMapFreeze(t *testing.T) {
+	om := New[string, int]()
+	om.Set("d", 3)
+	om.Set("b", 8)
+	om.Set("c", 5)
+
+	fm := om.Freeze()
+
+	om.Set("b", 0)
+	om.Set("a", 9)
+	om.Delete("d")
+	om.Reverse()
+
+	if fm.Len() != 3 {
+		t.Fatalf("wanted: %d, got: %d", 3, fm.Len())
+	}
+
+	expected := []string{"d", "b", "c"}
+	if keys := fm.Keys(); !slices.Equal(keys, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, keys)
+	}
+
+	if val, ok := fm.Get("b"); !ok || val != 8 {
+		t.Fatalf("get value, wanted: %d, got: %d", 8, val)
+	}
+
+	if val, ok := fm.Get("d"); !ok || val != 3 {
+		t.Fatalf("get value, wanted: %d, got: %d", 3, val)
+	}
+
+	if val, ok := fm.Get("a"); ok || val != 0 {
+		t.Fatalf("element with key %q should not exist", "a")
+	}
+
+	fm.Keys()[0] = "modified"
+
+	var keys []string
+	var values []int
+	next := fm.Iterator()
+	for k, v, ok := next(); ok; k, v, ok = next() {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+
+	if !slices.Equal(keys, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, keys)
+	}
+
+	if !slices.Equal(values, []int{3, 8, 5}) {
+		t.Fatalf("wanted: %d, got: %d", []int{3, 8, 5}, values)
+	}
+
+	if _, _, ok := New[string, int]().Freeze().Iterator()(); ok {
+		t.Fatalf("iterator over empty snapshot should not yield anything")
+	}
+}
@@ -0,0 +1,102 @@
+package orderedmap
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+)
+
+// gobMap is a representation of OrderedMap used for gob encoding.
+type gobMap[K comparable, V any] struct {
+	Keys   []K
+	Values []V
+}
+
+// GobEncode implements gob.GobEncoder interface.
+//
+// Keys and values are encoded as two parallel slices in keys insertion order,
+// so both K and V should be gob-encodable.
+func (om *OrderedMap[K, V]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(gobMap[K, V]{om.Keys(), om.Values()}); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder interface.
+//
+// Entries are added to a map in the same order in which they were encoded.
+func (om *OrderedMap[K, V]) GobDecode(data []byte) error {
+	var gm gobMap[K, V]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&gm); err != nil {
+		return err
+	}
+
+	if len(gm.Keys) != len(gm.Values) {
+		return fmt.Errorf("orderedmap: number of keys (%d) doesn't match number of values (%d)", len(gm.Keys), len(gm.Values))
+	}
+
+	om.lazyInit()
+
+	for i, key := range gm.Keys {
+		om.Set(key, gm.Values[i])
+	}
+
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+//
+// A map is encoded as a single gob stream which consists of a number of entries
+// followed by each entry in keys insertion order, so both K and V should be gob-encodable.
+// Each entry is encoded as a struct, so nil pointers, slices and maps are encoded as zero values
+// and are decoded back as nil.
+func (om *OrderedMap[K, V]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := gob.NewEncoder(&buf)
+	if err := enc.Encode(om.Len()); err != nil {
+		return nil, err
+	}
+
+	for curr := om.first(); curr != nil; curr = curr.next {
+		if err := enc.Encode(binaryEntry[K, V]{curr.value, om.data[curr.value].value}); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+//
+// It decodes data produced by MarshalBinary, entries are added to a map in the same order in which they were encoded.
+func (om *OrderedMap[K, V]) UnmarshalBinary(data []byte) error {
+	dec := gob.NewDecoder(bytes.NewReader(data))
+
+	var count int
+	if err := dec.Decode(&count); err != nil {
+		return err
+	}
+
+	om.lazyInit()
+
+	for i := 0; i < count; i++ {
+		var entry binaryEntry[K, V]
+		if err := dec.Decode(&entry); err != nil {
+			return err
+		}
+
+		om.Set(entry.Key, entry.Value)
+	}
+
+	return nil
+}
+
+// binaryEntry is a representation of a single map entry used by MarshalBinary.
+type binaryEntry[K comparable, V any] struct {
+	Key   K
+	Value V
+}
@@ -0,0 +1,185 @@
+package orderedmap
+
+import (
+	"bytes"
+	"encoding/gob"
+	"slices"
+	"testing"
+)
+
+func TestOrderedMapGob(t *testing.T) {
+	for _, keys := range [][]string{{}, {"d", "b", "c", "a"}} {
+		om := New[string, int]()
+		for i, k := range keys {
+			om.Set(k, i*10)
+		}
+
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(om); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var decoded OrderedMap[string, int]
+		if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !decoded.Equal(om, func(a, b int) bool { return a == b }) {
+			t.Fatalf("wanted: %v, got: %v", om, &decoded)
+		}
+	}
+
+	{
+		inner := New[string, int]()
+		inner.Set("z", 1)
+		inner.Set("y", 2)
+
+		om := New[string, *OrderedMap[string, int]]()
+		om.Set("b", inner)
+		om.Set("a", New[string, int]())
+
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(om); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		decoded := New[string, *OrderedMap[string, int]]()
+		if err := gob.NewDecoder(&buf).Decode(decoded); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if keys := decoded.Keys(); !slices.Equal(keys, []string{"b", "a"}) {
+			t.Fatalf("wanted: %q, got: %q", []string{"b", "a"}, keys)
+		}
+
+		if val, _ := decoded.Get("b"); val.String() != inner.String() {
+			t.Fatalf("wanted: %v, got: %v", inner, val)
+		}
+	}
+
+	{
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(gobMap[string, int]{Keys: []string{"a", "b"}, Values: []int{1}}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		decoded := New[string, int]()
+		if err := decoded.GobDecode(buf.Bytes()); err == nil {
+			t.Fatalf("decoding mismatched keys and values should fail")
+		}
+
+		if decoded.Len() != 0 {
+			t.Fatalf("wanted: %d, got: %d", 0, decoded.Len())
+		}
+	}
+}
+
+func TestOrderedMapBinary(t *testing.T) {
+	for _, keys := range [][]string{{}, {"d", "b", "c", "a"}} {
+		om := New[string, int]()
+		for i, k := range keys {
+			om.Set(k, i*10)
+		}
+
+		data, err := om.MarshalBinary()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var decoded OrderedMap[string, int]
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !decoded.Equal(om, func(a, b int) bool { return a == b }) {
+			t.Fatalf("wanted: %v, got: %v", om, &decoded)
+		}
+	}
+
+	{
+		om := New[int, []string]()
+		om.Set(3, []string{"c"})
+		om.Set(1, nil)
+		om.Set(2, []string{"a", "b"})
+
+		data, err := om.MarshalBinary()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		decoded := New[int, []string]()
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if decoded.String() != om.String() {
+			t.Fatalf("wanted: %v, got: %v", om, decoded)
+		}
+	}
+
+	{
+		one := 1
+		om := New[string, *int]()
+		om.Set("a", nil)
+		om.Set("b", &one)
+		om.Set("c", &one)
+
+		data, err := om.MarshalBinary()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		decoded := New[string, *int]()
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if keys := decoded.Keys(); !slices.Equal(keys, []string{"a", "b", "c"}) {
+			t.Fatalf("wanted: %q, got: %q", []string{"a", "b", "c"}, keys)
+		}
+
+		if val, ok := decoded.Get("a"); !ok || val != nil {
+			t.Fatalf("get value, wanted: %v, got: %v", nil, val)
+		}
+
+		for _, k := range []string{"b", "c"} {
+			if val, _ := decoded.Get(k); val == nil || *val != 1 {
+				t.Fatalf("get value for %q, wanted: %d, got: %v", k, 1, val)
+			}
+		}
+	}
+
+	if err := New[string, int]().UnmarshalBinary([]byte("garbage")); err == nil {
+		t.Fatalf("unmarshaling invalid data should fail")
+	}
+}
+
+func TestOrderedMapGobZeroValue(t *testing.T) {
+	var s struct{ M OrderedMap[string, int] }
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded struct{ M OrderedMap[string, int] }
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if decoded.M.Len() != 0 {
+		t.Fatalf("wanted: %d, got: %d", 0, decoded.M.Len())
+	}
+
+	var om OrderedMap[string, int]
+
+	data, err := om.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	restored := New[string, int]()
+	if err := restored.UnmarshalBinary(data); err != nil || restored.Len() != 0 {
+		t.Fatalf("wanted: %d, got: %d, error: %v", 0, restored.Len(), err)
+	}
+}
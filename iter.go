@@ -0,0 +1,98 @@
+package orderedmap
+
+import "iter"
+
+// All returns an iterator over key->value pairs of a map in keys insertion order.
+//
+// Example:
+//
+//	for k, v := range om.All() {
+//	  fmt.Printf("key: %v, value: %v", k, v)
+//	}
+//
+// NOTE: if a map is modified when iteration is in progress, the result is undefined.
+func (om *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for curr := om.items.head; curr != nil; curr = curr.next {
+			if !yield(curr.value, om.data[curr.value].value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over key->value pairs of a map in reverse keys insertion order.
+//
+// NOTE: if a map is modified when iteration is in progress, the result is undefined.
+func (om *OrderedMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for curr := om.items.tail; curr != nil; curr = curr.prev {
+			if !yield(curr.value, om.data[curr.value].value) {
+				return
+			}
+		}
+	}
+}
+
+// AllSnapshot returns an iterator over key->value pairs of a map in keys insertion order, the same way as All does.
+//
+// Unlike All, it is safe to modify a map when iteration is in progress, e.g. to delete entries in a loop body:
+// keys are captured when iteration starts, while values are retrieved when the corresponding key is visited.
+// Keys deleted after iteration started are skipped, keys added after that are not visited.
+//
+// NOTE: capturing keys takes linear time and memory.
+func (om *OrderedMap[K, V]) AllSnapshot() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, key := range om.Keys() {
+			elem, ok := om.data[key]
+			if !ok {
+				continue
+			}
+
+			if !yield(key, elem.value) {
+				return
+			}
+		}
+	}
+}
+
+// KeysSeq returns an iterator over keys of a map in keys insertion order.
+// Unlike Keys, it doesn't allocate a slice.
+//
+// NOTE: if a map is modified when iteration is in progress, the result is undefined.
+func (om *OrderedMap[K, V]) KeysSeq() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for curr := om.items.head; curr != nil; curr = curr.next {
+			if !yield(curr.value) {
+				return
+			}
+		}
+	}
+}
+
+// ValuesSeq returns an iterator over values of a map in keys insertion order.
+// Unlike Values, it doesn't allocate a slice.
+//
+// NOTE: if a map is modified when iteration is in progress, the result is undefined.
+func (om *OrderedMap[K, V]) ValuesSeq() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for curr := om.items.head; curr != nil; curr = curr.next {
+			if !yield(om.data[curr.value].value) {
+				return
+			}
+		}
+	}
+}
+
+// EntriesSeq returns an iterator over key->value entries of a map in keys insertion order.
+//
+// NOTE: if a map is modified when iteration is in progress, the result is undefined.
+func (om *OrderedMap[K, V]) EntriesSeq() iter.Seq[Pair[K, V]] {
+	return func(yield func(Pair[K, V]) bool) {
+		for curr := om.items.head; curr != nil; curr = curr.next {
+			if !yield(Pair[K, V]{curr.value, om.data[curr.value].value}) {
+				return
+			}
+		}
+	}
+}
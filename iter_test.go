@@ -0,0 +1,218 @@
+package orderedmap
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestOrderedMapAll(t *testing.T) {
+	om := New[string, int]()
+
+	for k, v := range om.All() {
+		t.Fatalf("iterator over empty map should not yield anything, got: (%q, %d)", k, v)
+	}
+
+	expected := []string{"d", "b", "c", "a"}
+	for i, k := range expected {
+		om.Set(k, i)
+	}
+
+	var keys []string
+	for k, v := range om.All() {
+		if v != len(keys) {
+			t.Fatalf("wanted: %d, got: %d", len(keys), v)
+		}
+		keys = append(keys, k)
+	}
+
+	if !slices.Equal(keys, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, keys)
+	}
+
+	keys = keys[:0]
+	for k := range om.All() {
+		if k == "c" {
+			break
+		}
+		keys = append(keys, k)
+	}
+
+	if !slices.Equal(keys, expected[:2]) {
+		t.Fatalf("wanted: %q, got: %q", expected[:2], keys)
+	}
+}
+
+func TestOrderedMapBackward(t *testing.T) {
+	om := New[string, int]()
+
+	for k, v := range om.Backward() {
+		t.Fatalf("iterator over empty map should not yield anything, got: (%q, %d)", k, v)
+	}
+
+	for i, k := range []string{"d", "b", "c", "a"} {
+		om.Set(k, i)
+	}
+
+	expected := []string{"a", "c", "b", "d"}
+
+	var keys []string
+	for k, v := range om.Backward() {
+		if v != len(expected)-len(keys)-1 {
+			t.Fatalf("wanted: %d, got: %d", len(expected)-len(keys)-1, v)
+		}
+		keys = append(keys, k)
+	}
+
+	if !slices.Equal(keys, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, keys)
+	}
+
+	keys = keys[:0]
+	for k := range om.Backward() {
+		if k == "b" {
+			break
+		}
+		keys = append(keys, k)
+	}
+
+	if !slices.Equal(keys, expected[:2]) {
+		t.Fatalf("wanted: %q, got: %q", expected[:2], keys)
+	}
+}
+
+func TestOrderedMapKeysSeq(t *testing.T) {
+	om := New[string, int]()
+
+	for k := range om.KeysSeq() {
+		t.Fatalf("iterator over empty map should not yield anything, got: %q", k)
+	}
+
+	expected := []string{"d", "b", "c", "a"}
+	for i, k := range expected {
+		om.Set(k, i)
+	}
+
+	if keys := slices.Collect(om.KeysSeq()); !slices.Equal(keys, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, keys)
+	}
+
+	var keys []string
+	for k := range om.KeysSeq() {
+		if k == "c" {
+			break
+		}
+		keys = append(keys, k)
+	}
+
+	if !slices.Equal(keys, expected[:2]) {
+		t.Fatalf("wanted: %q, got: %q", expected[:2], keys)
+	}
+}
+
+func TestOrderedMapValuesSeq(t *testing.T) {
+	om := New[string, int]()
+
+	for v := range om.ValuesSeq() {
+		t.Fatalf("iterator over empty map should not yield anything, got: %d", v)
+	}
+
+	om.Set("d", 3)
+	om.Set("b", 8)
+	om.Set("c", 5)
+	om.Set("a", 9)
+
+	expected := []int{3, 8, 5, 9}
+	if values := slices.Collect(om.ValuesSeq()); !slices.Equal(values, expected) {
+		t.Fatalf("wanted: %d, got: %d", expected, values)
+	}
+
+	var values []int
+	for v := range om.ValuesSeq() {
+		if v == 5 {
+			break
+		}
+		values = append(values, v)
+	}
+
+	if !slices.Equal(values, expected[:2]) {
+		t.Fatalf("wanted: %d, got: %d", expected[:2], values)
+	}
+}
+
+func TestOrderedMapEntriesSeq(t *testing.T) {
+	om := New[string, int]()
+
+	for p := range om.EntriesSeq() {
+		t.Fatalf("iterator over empty map should not yield anything, got: %v", p)
+	}
+
+	om.Set("d", 3)
+	om.Set("b", 8)
+	om.Set("c", 5)
+
+	expected := []Pair[string, int]{{"d", 3}, {"b", 8}, {"c", 5}}
+	if pairs := slices.Collect(om.EntriesSeq()); !slices.Equal(pairs, expected) {
+		t.Fatalf("wanted: %v, got: %v", expected, pairs)
+	}
+
+	var pairs []Pair[string, int]
+	for p := range om.EntriesSeq() {
+		if p.Key == "b" {
+			break
+		}
+		pairs = append(pairs, p)
+	}
+
+	if !slices.Equal(pairs, expected[:1]) {
+		t.Fatalf("wanted: %v, got: %v", expected[:1], pairs)
+	}
+}
+
+func TestOrderedMapAllSnapshot(t *testing.T) {
+	om := New[string, int]()
+	for i, k := range []string{"a", "b", "c", "d", "e"} {
+		om.Set(k, i)
+	}
+
+	var keys []string
+	var values []int
+	for k, v := range om.AllSnapshot() {
+		keys = append(keys, k)
+		values = append(values, v)
+
+		switch k {
+		case "a":
+			om.Delete("a")
+			om.Delete("c")
+			om.Set("d", 30)
+			om.Set("f", 5)
+		case "b":
+			om.Delete("e")
+		}
+	}
+
+	if !slices.Equal(keys, []string{"a", "b", "d"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"a", "b", "d"}, keys)
+	}
+
+	if !slices.Equal(values, []int{0, 1, 30}) {
+		t.Fatalf("wanted: %v, got: %v", []int{0, 1, 30}, values)
+	}
+
+	if k := om.Keys(); !slices.Equal(k, []string{"b", "d", "f"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"b", "d", "f"}, k)
+	}
+
+	for k := range om.AllSnapshot() {
+		om.Delete(k)
+		break
+	}
+
+	if k := om.Keys(); !slices.Equal(k, []string{"d", "f"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"d", "f"}, k)
+	}
+
+	for k := range New[string, int]().AllSnapshot() {
+		t.Fatalf("iterator over empty map should not yield anything, got: %q", k)
+	}
+}
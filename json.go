@@ -0,0 +1,284 @@
+package orderedmap
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+// MarshalJSON implements json.Marshaler interface.
+//
+// A map is encoded as a JSON object with keys written in keys insertion order.
+// Keys are encoded the same way encoding/json encodes keys of a regular map:
+//   - keys of any string type are used directly;
+//   - keys implementing encoding.TextMarshaler are marshaled to text;
+//   - keys of any integer type are converted to strings.
+//
+// Values are encoded using json.Marshal.
+func (om *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+
+	for curr := om.first(); curr != nil; curr = curr.next {
+		if curr != om.first() {
+			buf.WriteByte(',')
+		}
+
+		key, err := marshalKey(curr.value)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(data)
+		buf.WriteByte(':')
+
+		data, err = json.Marshal(om.data[curr.value].value)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(data)
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+//
+// Entries are added to a map in the order in which keys appear in a JSON object.
+// Keys are decoded the same way encoding/json decodes keys of a regular map:
+//   - keys implementing encoding.TextUnmarshaler are unmarshaled from text;
+//   - keys of any string type are used directly;
+//   - keys of any integer type are parsed from strings.
+//
+// Values are decoded into V using encoding/json.
+//
+// If a key appears in a JSON object more than once, the last value is kept,
+// but the key retains the position of its first occurrence (the same way Set works).
+func (om *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("orderedmap: cannot unmarshal %v into an ordered map, JSON object expected", tok)
+	}
+
+	om.lazyInit()
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		key, err := unmarshalKey[K](tok.(string))
+		if err != nil {
+			return err
+		}
+
+		var val V
+		if err := dec.Decode(&val); err != nil {
+			return err
+		}
+
+		om.Set(key, val)
+	}
+
+	_, err = dec.Token()
+	return err
+}
+
+// WriteJSONLines writes a map to `w` in JSON Lines format: one `{"key":...,"value":...}` object per line,
+// in keys insertion order. Entries are encoded one at a time using json.Encoder,
+// so the whole document is never built in memory.
+//
+// Keys and values are encoded using encoding/json.
+//
+// Returns:
+//   - an error if an entry cannot be encoded or if writing to `w` fails.
+func (om *OrderedMap[K, V]) WriteJSONLines(w io.Writer) error {
+	enc := json.NewEncoder(w)
+
+	for curr := om.first(); curr != nil; curr = curr.next {
+		if err := enc.Encode(jsonLine[K, V]{curr.value, om.data[curr.value].value}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type jsonLine[K comparable, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+// ParseJSONObject decodes an arbitrary JSON object preserving keys order at every level of nesting.
+//
+// Nested JSON objects are decoded into nested *OrderedMap[string, any] values, JSON arrays are decoded into []any.
+// All other values are decoded the same way encoding/json decodes them into an interface value:
+// strings into string, numbers into float64, booleans into bool and null into nil.
+//
+// If a key appears in a JSON object more than once, the last value is kept,
+// but the key retains the position of its first occurrence (the same way Set works).
+//
+// Returns:
+//   - (map, nil) if `data` contains a valid JSON object;
+//   - (nil, error) otherwise.
+func ParseJSONObject(data []byte) (*OrderedMap[string, any], error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, fmt.Errorf("orderedmap: cannot parse %v into an ordered map, JSON object expected", tok)
+	}
+
+	om, err := parseObject(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("orderedmap: unexpected data after JSON object")
+	}
+
+	return om, nil
+}
+
+func parseValue(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		return parseObject(dec)
+	case json.Delim('['):
+		return parseArray(dec)
+	}
+
+	return tok, nil
+}
+
+func parseObject(dec *json.Decoder) (*OrderedMap[string, any], error) {
+	om := New[string, any]()
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		val, err := parseValue(dec)
+		if err != nil {
+			return nil, err
+		}
+
+		om.Set(tok.(string), val)
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	return om, nil
+}
+
+func parseArray(dec *json.Decoder) ([]any, error) {
+	arr := []any{}
+
+	for dec.More() {
+		val, err := parseValue(dec)
+		if err != nil {
+			return nil, err
+		}
+
+		arr = append(arr, val)
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	return arr, nil
+}
+
+func marshalKey[K comparable](key K) (string, error) {
+	if rv := reflect.ValueOf(key); rv.Kind() == reflect.String {
+		return rv.String(), nil
+	}
+
+	if tm, ok := any(key).(encoding.TextMarshaler); ok {
+		data, err := tm.MarshalText()
+		if err != nil {
+			return "", err
+		}
+
+		return string(data), nil
+	}
+
+	switch rv := reflect.ValueOf(key); rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	}
+
+	return "", fmt.Errorf("orderedmap: unsupported key type %T", key)
+}
+
+func unmarshalKey[K comparable](s string) (K, error) {
+	var key K
+
+	if tu, ok := any(&key).(encoding.TextUnmarshaler); ok {
+		err := tu.UnmarshalText([]byte(s))
+		return key, err
+	}
+
+	switch rv := reflect.ValueOf(&key).Elem(); rv.Kind() {
+	case reflect.String:
+		rv.SetString(s)
+		return key, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || rv.OverflowInt(n) {
+			return key, fmt.Errorf("orderedmap: cannot unmarshal %q into key of type %T", s, key)
+		}
+
+		rv.SetInt(n)
+		return key, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil || rv.OverflowUint(n) {
+			return key, fmt.Errorf("orderedmap: cannot unmarshal %q into key of type %T", s, key)
+		}
+
+		rv.SetUint(n)
+		return key, nil
+	}
+
+	return key, fmt.Errorf("orderedmap: unsupported key type %T", key)
+}
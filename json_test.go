@@ -0,0 +1,324 @@
+package orderedmap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testID struct {
+	prefix string
+	num    int
+}
+
+func (id testID) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%s-%d", id.prefix, id.num)), nil
+}
+
+func (id *testID) UnmarshalText(data []byte) error {
+	prefix, num, ok := strings.Cut(string(data), "-")
+	if !ok {
+		return fmt.Errorf("invalid id: %q", data)
+	}
+
+	id.prefix = prefix
+	_, err := fmt.Sscan(num, &id.num)
+	return err
+}
+
+func TestOrderedMapMarshalJSON(t *testing.T) {
+	{
+		data, err := json.Marshal(New[string, int]())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if string(data) != "{}" {
+			t.Fatalf("wanted: %s, got: %s", "{}", data)
+		}
+	}
+
+	{
+		inner := New[string, any]()
+		inner.Set("z", []int{1, 2})
+		inner.Set("a", nil)
+
+		om := New[string, any]()
+		om.Set("d", 3)
+		om.Set("b", "text")
+		om.Set("c", inner)
+		om.Set("a", true)
+
+		const expected = `{"d":3,"b":"text","c":{"z":[1,2],"a":null},"a":true}`
+
+		data, err := json.Marshal(om)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if string(data) != expected {
+			t.Fatalf("wanted: %s, got: %s", expected, data)
+		}
+	}
+
+	{
+		om := New[struct{ id int }, int]()
+		om.Set(struct{ id int }{1}, 1)
+
+		if _, err := json.Marshal(om); err == nil {
+			t.Fatalf("marshaling a map with unsupported key type should fail")
+		}
+	}
+}
+
+func TestOrderedMapUnmarshalJSON(t *testing.T) {
+	{
+		om := New[string, int]()
+		if err := json.Unmarshal([]byte(`{"d":3,"b":8,"c":5,"b":9}`), om); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expectedKeys := []string{"d", "b", "c"}
+		if keys := om.Keys(); !reflect.DeepEqual(keys, expectedKeys) {
+			t.Fatalf("wanted: %q, got: %q", expectedKeys, keys)
+		}
+
+		expectedVals := []int{3, 9, 5}
+		if values := om.Values(); !reflect.DeepEqual(values, expectedVals) {
+			t.Fatalf("wanted: %d, got: %d", expectedVals, values)
+		}
+	}
+
+	{
+		var cfg struct {
+			Data *OrderedMap[string, *OrderedMap[string, string]] `json:"data"`
+		}
+
+		const input = `{"data":{"z":{"y":"1","x":"2"},"a":{}}}`
+		if err := json.Unmarshal([]byte(input), &cfg); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if keys := cfg.Data.Keys(); !reflect.DeepEqual(keys, []string{"z", "a"}) {
+			t.Fatalf("wanted: %q, got: %q", []string{"z", "a"}, keys)
+		}
+
+		inner, _ := cfg.Data.Get("z")
+		if keys := inner.Keys(); !reflect.DeepEqual(keys, []string{"y", "x"}) {
+			t.Fatalf("wanted: %q, got: %q", []string{"y", "x"}, keys)
+		}
+
+		data, err := json.Marshal(cfg)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if string(data) != input {
+			t.Fatalf("wanted: %s, got: %s", input, data)
+		}
+	}
+
+	for _, input := range []string{`[1, 2]`, `"text"`, `{"a":"text"}`} {
+		om := New[string, int]()
+		if err := json.Unmarshal([]byte(input), om); err == nil {
+			t.Fatalf("unmarshaling %s should fail", input)
+		}
+	}
+}
+
+func TestOrderedMapJSONKeys(t *testing.T) {
+	{
+		om := New[testID, int]()
+		om.Set(testID{"z", 2}, 0)
+		om.Set(testID{"a", 10}, 1)
+		om.Set(testID{"m", 1}, 2)
+
+		const expected = `{"z-2":0,"a-10":1,"m-1":2}`
+
+		data, err := json.Marshal(om)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if string(data) != expected {
+			t.Fatalf("wanted: %s, got: %s", expected, data)
+		}
+
+		decoded := New[testID, int]()
+		if err := json.Unmarshal(data, decoded); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !decoded.Equal(om, func(a, b int) bool { return a == b }) {
+			t.Fatalf("wanted: %v, got: %v", om, decoded)
+		}
+
+		if err := json.Unmarshal([]byte(`{"invalid":1}`), decoded); err == nil {
+			t.Fatalf("unmarshaling invalid key should fail")
+		}
+	}
+
+	{
+		om := New[int8, string]()
+		om.Set(3, "c")
+		om.Set(-1, "a")
+		om.Set(2, "b")
+
+		const expected = `{"3":"c","-1":"a","2":"b"}`
+
+		data, err := json.Marshal(om)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if string(data) != expected {
+			t.Fatalf("wanted: %s, got: %s", expected, data)
+		}
+
+		decoded := New[int8, string]()
+		if err := json.Unmarshal(data, decoded); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if decoded.String() != om.String() {
+			t.Fatalf("wanted: %v, got: %v", om, decoded)
+		}
+
+		for _, input := range []string{`{"128":"x"}`, `{"a":"x"}`} {
+			if err := json.Unmarshal([]byte(input), decoded); err == nil {
+				t.Fatalf("unmarshaling %s should fail", input)
+			}
+		}
+	}
+
+	{
+		om := New[uint, bool]()
+		if err := json.Unmarshal([]byte(`{"7":true,"1":false}`), om); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if keys := om.Keys(); !reflect.DeepEqual(keys, []uint{7, 1}) {
+			t.Fatalf("wanted: %d, got: %d", []uint{7, 1}, keys)
+		}
+
+		if err := json.Unmarshal([]byte(`{"-1":true}`), om); err == nil {
+			t.Fatalf("unmarshaling negative unsigned key should fail")
+		}
+	}
+}
+
+func TestOrderedMapWriteJSONLines(t *testing.T) {
+	om := New[string, int]()
+	om.Set("d", 3)
+	om.Set("b", 8)
+	om.Set("c", 5)
+
+	var sb strings.Builder
+	if err := om.WriteJSONLines(&sb); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "{\"key\":\"d\",\"value\":3}\n{\"key\":\"b\",\"value\":8}\n{\"key\":\"c\",\"value\":5}\n"
+	if got := sb.String(); got != expected {
+		t.Fatalf("wanted: %q, got: %q", expected, got)
+	}
+
+	sb.Reset()
+	if err := New[string, int]().WriteJSONLines(&sb); err != nil || sb.Len() != 0 {
+		t.Fatalf("empty map should produce no output, got: %q, error: %v", sb.String(), err)
+	}
+
+	if err := om.WriteJSONLines(failingWriter{}); !errors.Is(err, errWrite) {
+		t.Fatalf("wanted: %v, got: %v", errWrite, err)
+	}
+}
+
+func TestParseJSONObject(t *testing.T) {
+	data := `{"z":1,"a":{"y":"s","b":{"x":true,"c":null}},"m":[{"q":1,"p":2},3,["r"]]}`
+
+	om, err := ParseJSONObject([]byte(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if keys := om.Keys(); !reflect.DeepEqual(keys, []string{"z", "a", "m"}) {
+		t.Fatalf("top level keys, wanted: %q, got: %q", []string{"z", "a", "m"}, keys)
+	}
+
+	if v, _ := om.Get("z"); v != 1.0 {
+		t.Fatalf("get value, wanted: %v, got: %v", 1.0, v)
+	}
+
+	v, _ := om.Get("a")
+	a, ok := v.(*OrderedMap[string, any])
+	if !ok {
+		t.Fatalf("nested object should be decoded into an ordered map, got: %T", v)
+	}
+
+	if keys := a.Keys(); !reflect.DeepEqual(keys, []string{"y", "b"}) {
+		t.Fatalf("second level keys, wanted: %q, got: %q", []string{"y", "b"}, keys)
+	}
+
+	v, _ = a.Get("b")
+	b := v.(*OrderedMap[string, any])
+
+	if keys := b.Keys(); !reflect.DeepEqual(keys, []string{"x", "c"}) {
+		t.Fatalf("third level keys, wanted: %q, got: %q", []string{"x", "c"}, keys)
+	}
+
+	if v, ok := b.Get("c"); !ok || v != nil {
+		t.Fatalf("get value, wanted: %v, got: %v", nil, v)
+	}
+
+	v, _ = om.Get("m")
+	m, ok := v.([]any)
+	if !ok || len(m) != 3 {
+		t.Fatalf("array should be decoded into []any of length 3, got: %#v", v)
+	}
+
+	if keys := m[0].(*OrderedMap[string, any]).Keys(); !reflect.DeepEqual(keys, []string{"q", "p"}) {
+		t.Fatalf("array element keys, wanted: %q, got: %q", []string{"q", "p"}, keys)
+	}
+
+	if !reflect.DeepEqual(m[2], []any{"r"}) {
+		t.Fatalf("wanted: %v, got: %v", []any{"r"}, m[2])
+	}
+
+	out, err := json.Marshal(om)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(out) != data {
+		t.Fatalf("wanted: %s, got: %s", data, out)
+	}
+
+	for _, input := range []string{`[1,2]`, `"s"`, `{"a":1`, `{"a":1}{}`, ``} {
+		if _, err := ParseJSONObject([]byte(input)); err == nil {
+			t.Fatalf("parsing %q should fail", input)
+		}
+	}
+}
+
+func TestOrderedMapJSONZeroValue(t *testing.T) {
+	var s struct{ M OrderedMap[string, int] }
+
+	data, err := json.Marshal(&s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(data) != `{"M":{}}` {
+		t.Fatalf("wanted: %s, got: %s", `{"M":{}}`, data)
+	}
+
+	var om OrderedMap[string, int]
+
+	var sb strings.Builder
+	if err := om.WriteJSONLines(&sb); err != nil || sb.Len() != 0 {
+		t.Fatalf("zero value map should produce no output, got: %q, error: %v", sb.String(), err)
+	}
+}
@@ -0,0 +1,54 @@
+package orderedmap
+
+import "github.com/vmihailenco/msgpack/v5"
+
+// EncodeMsgpack implements msgpack.CustomEncoder interface.
+//
+// A map is encoded as a msgpack map with keys written in keys insertion order.
+func (om *OrderedMap[K, V]) EncodeMsgpack(enc *msgpack.Encoder) error {
+	if err := enc.EncodeMapLen(om.Len()); err != nil {
+		return err
+	}
+
+	for curr := om.first(); curr != nil; curr = curr.next {
+		if err := enc.Encode(curr.value); err != nil {
+			return err
+		}
+
+		if err := enc.Encode(om.data[curr.value].value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder interface.
+//
+// Entries are added to a map in the order in which keys appear in a msgpack map.
+// If a key appears in a msgpack map more than once, the last value is kept,
+// but the key retains the position of its first occurrence (the same way Set works).
+func (om *OrderedMap[K, V]) DecodeMsgpack(dec *msgpack.Decoder) error {
+	n, err := dec.DecodeMapLen()
+	if err != nil || n == -1 {
+		return err
+	}
+
+	om.lazyInit()
+
+	for i := 0; i < n; i++ {
+		var key K
+		if err := dec.Decode(&key); err != nil {
+			return err
+		}
+
+		var val V
+		if err := dec.Decode(&val); err != nil {
+			return err
+		}
+
+		om.Set(key, val)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package orderedmap
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestOrderedMapMsgpack(t *testing.T) {
+	inner := New[string, int]()
+	inner.Set("z", 1)
+	inner.Set("y", 2)
+
+	om := New[string, *OrderedMap[string, int]]()
+	om.Set("d", inner)
+	om.Set("b", New[string, int]())
+	om.Set("c", inner)
+
+	data, err := msgpack.Marshal(om)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	decoded := New[string, *OrderedMap[string, int]]()
+	if err := msgpack.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if keys := decoded.Keys(); !slices.Equal(keys, []string{"d", "b", "c"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"d", "b", "c"}, keys)
+	}
+
+	for k, v := range om.All() {
+		if val, _ := decoded.Get(k); val.String() != v.String() {
+			t.Fatalf("get value, wanted: %v, got: %v", v, val)
+		}
+	}
+
+	var cfg struct {
+		Data *OrderedMap[int, string]
+	}
+
+	data, err = msgpack.Marshal(map[string]any{"Data": map[int]string{1: "a"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := msgpack.Unmarshal(data, &cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if s := cfg.Data.String(); s != "OrderedMap[1:a]" {
+		t.Fatalf("wanted: %s, got: %s", "OrderedMap[1:a]", s)
+	}
+
+	if err := msgpack.Unmarshal([]byte{0x91, 0x01}, New[string, int]()); err == nil {
+		t.Fatalf("unmarshaling an array into an ordered map should fail")
+	}
+}
+
+func TestOrderedMapMsgpackZeroValue(t *testing.T) {
+	var s struct{ M OrderedMap[string, int] }
+
+	data, err := msgpack.Marshal(&s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded struct{ M OrderedMap[string, int] }
+	if err := msgpack.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if decoded.M.Len() != 0 {
+		t.Fatalf("wanted: %d, got: %d", 0, decoded.M.Len())
+	}
+}
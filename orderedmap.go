@@ -1,83 +1,1017 @@
 package orderedmap
 
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
 // OrderedMap represents a hash map which maintains key insertion order.
 //   K - type of keys, should satisfy 'comparable' constraint
 //   V - value type, has no restrictions
 //
 // NOTE: This type is NOT thread-safe.
 type OrderedMap[K comparable, V any] struct {
-	data  map[K]*element[K, V]
-	items *list[K]
+	data     map[K]*element[K, V]
+	items    *list[K]
+	capacity int
+	onEvict  func(K, V)
+}
+
+// Pair represents a single key->value entry of a map.
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// New creates a new instance of OrderedMap and returns a pointer to it.
+func New[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{
+		data:  make(map[K]*element[K, V]),
+		items: &list[K]{},
+	}
+}
+
+// NewWithCapacity creates a new instance of OrderedMap which holds at most `max` entries
+// and returns a pointer to it.
+//
+// When a new key is added to a full map, the oldest entry (i.e. the one at the beginning
+// of the keys list) is evicted to make room for it. Updating an existing key never causes eviction.
+// Use OnEvict to get notified about evicted entries.
+//
+// NewWithCapacity panics if `max` is less than 1.
+func NewWithCapacity[K comparable, V any](max int) *OrderedMap[K, V] {
+	if max < 1 {
+		panic("orderedmap: capacity must be positive")
+	}
+
+	om := New[K, V]()
+	om.capacity = max
+
+	return om
+}
+
+// FromMapSorted creates a new instance of OrderedMap with all key->value entries of `m`
+// and returns a pointer to it. Keys are added in ascending order.
+func FromMapSorted[K cmp.Ordered, V any](m map[K]V) *OrderedMap[K, V] {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	om := &OrderedMap[K, V]{
+		data:  make(map[K]*element[K, V], len(m)),
+		items: &list[K]{},
+	}
+
+	for _, key := range keys {
+		om.Set(key, m[key])
+	}
+
+	return om
+}
+
+// Zip creates a new instance of OrderedMap from parallel slices of keys and values and returns a pointer to it.
+//
+// Entries are added in slice order the same way as by Set, i.e. for duplicate keys
+// the last value is kept, while the key retains the position of its first occurrence.
+// An error is returned if lengths of `keys` and `values` differ.
+func Zip[K comparable, V any](keys []K, values []V) (*OrderedMap[K, V], error) {
+	if len(keys) != len(values) {
+		return nil, fmt.Errorf("orderedmap: number of keys (%d) doesn't match number of values (%d)", len(keys), len(values))
+	}
+
+	om := &OrderedMap[K, V]{
+		data:  make(map[K]*element[K, V], len(keys)),
+		items: &list[K]{},
+	}
+
+	for i, key := range keys {
+		om.Set(key, values[i])
+	}
+
+	return om, nil
+}
+
+// OnEvict registers a function which is called with a key and a value of each entry
+// evicted from a map created with NewWithCapacity. Passing nil removes a previously registered function.
+func (om *OrderedMap[K, V]) OnEvict(fn func(key K, value V)) {
+	om.onEvict = fn
+}
+
+// Get retrieves a value corresponding to `key`.
+//
+// Parameters:
+//   - `key` - a key in the map.
+//
+// Returns:
+//   - (value, true) if corresponding key->value pair is present in a map;
+//   - (<zero>, false) is returned otherwise, where <zero> represents a default value for type V.
+func (om *OrderedMap[K, V]) Get(key K) (V, bool) {
+	if elem, ok := om.data[key]; ok {
+		return elem.value, true
+	}
+
+	var def V
+	return def, false
+}
+
+// GetOrDefault retrieves a value corresponding to `key` or returns `def` if `key` is not present in a map.
+// Unlike GetOrSet, it never modifies a map.
+func (om *OrderedMap[K, V]) GetOrDefault(key K, def V) V {
+	if elem, ok := om.data[key]; ok {
+		return elem.value
+	}
+
+	return def
+}
+
+// GetLRU retrieves a value corresponding to `key` and moves `key` to the end of the keys list.
+//
+// This allows to use a map as an LRU cache: the least recently used key is always
+// at the beginning of the keys list, while the most recently used one is at the end.
+// Note that after calling GetLRU the keys order no longer reflects insertion order.
+// Use Get to retrieve a value without changing keys order.
+//
+// Parameters:
+//   - `key` - a key in the map.
+//
+// Returns:
+//   - (value, true) if corresponding key->value pair is present in a map;
+//   - (<zero>, false) is returned otherwise, where <zero> represents a default value for type V.
+func (om *OrderedMap[K, V]) GetLRU(key K) (V, bool) {
+	if elem, ok := om.data[key]; ok {
+		om.items.remove(elem.item)
+		om.items.push(elem.item)
+		return elem.value, true
+	}
+
+	var def V
+	return def, false
+}
+
+// Peek retrieves a value corresponding to `key` without changing keys order.
+//
+// It works exactly the same way as Get and is provided for readability in code which uses GetLRU:
+// unlike GetLRU, Peek never moves `key` to the end of the keys list.
+func (om *OrderedMap[K, V]) Peek(key K) (V, bool) {
+	return om.Get(key)
+}
+
+// Front returns the first entry of a map (i.e. the entry with the oldest key).
+//
+// Returns:
+//   - (key, value, true) if a map is not empty;
+//   - (<zero>, <zero>, false) otherwise, where <zero> represents a default value for types K and V.
+func (om *OrderedMap[K, V]) Front() (K, V, bool) {
+	return om.entry(om.items.head)
+}
+
+// Back returns the last entry of a map (i.e. the entry with the most recently inserted key).
+//
+// Returns:
+//   - (key, value, true) if a map is not empty;
+//   - (<zero>, <zero>, false) otherwise, where <zero> represents a default value for types K and V.
+func (om *OrderedMap[K, V]) Back() (K, V, bool) {
+	return om.entry(om.items.tail)
+}
+
+// Next returns an entry which follows `key` in keys insertion order.
+//
+// Returns:
+//   - (key, value, true) if `key` is present in a map and is not the last one;
+//   - (<zero>, <zero>, false) otherwise, where <zero> represents a default value for types K and V.
+func (om *OrderedMap[K, V]) Next(key K) (K, V, bool) {
+	if elem, ok := om.data[key]; ok {
+		return om.entry(elem.item.next)
+	}
+
+	return om.entry(nil)
+}
+
+// Prev returns an entry which precedes `key` in keys insertion order.
+//
+// Returns:
+//   - (key, value, true) if `key` is present in a map and is not the first one;
+//   - (<zero>, <zero>, false) otherwise, where <zero> represents a default value for types K and V.
+func (om *OrderedMap[K, V]) Prev(key K) (K, V, bool) {
+	if elem, ok := om.data[key]; ok {
+		return om.entry(elem.item.prev)
+	}
+
+	return om.entry(nil)
+}
+
+// At returns an entry at a given position in keys insertion order.
+//
+// NOTE: this operation takes linear time, because it requires walking the keys list.
+//
+// Parameters:
+//   - `index` - zero-based position of the entry.
+//
+// Returns:
+//   - (key, value, true) if `index` is within [0, Len()) range;
+//   - (<zero>, <zero>, false) otherwise, where <zero> represents a default value for types K and V.
+func (om *OrderedMap[K, V]) At(index int) (K, V, bool) {
+	return om.entry(om.items.at(index))
+}
+
+// IndexOf returns a zero-based position of `key` in keys insertion order or -1 if `key` is not present in a map.
+//
+// NOTE: this operation takes linear time, because it requires walking the keys list.
+func (om *OrderedMap[K, V]) IndexOf(key K) int {
+	elem, ok := om.data[key]
+	if !ok {
+		return -1
+	}
+
+	index := 0
+	for curr := elem.item.prev; curr != nil; curr = curr.prev {
+		index++
+	}
+
+	return index
+}
+
+// Has reports whether `key` is present in a map.
+func (om *OrderedMap[K, V]) Has(key K) bool {
+	_, ok := om.data[key]
+	return ok
+}
+
+// Set adds a key->value entry to a map.
+//
+// If `key` is already present in a map, corresponding entry is updated with a new value.
+// In this case insertion order of keys is not changed (i.e. `key` is not moved to the end of the keys list).
+//
+// If a map was created with NewWithCapacity and is full, the oldest entry is evicted before a new `key` is added.
+//
+// Parameters:
+//   - `key` - map entry key.
+//   - `value` - map entry value.
+//
+// Returns:
+//   - (old, true) if `key` already existed in a map, where `old` is a previous value of the entry;
+//   - (<zero>, false) if `key` didn't exist before where <zero> represents a default value for type V.
+func (om *OrderedMap[K, V]) Set(key K, value V) (V, bool) {
+	if old, ok := om.data[key]; ok {
+		prev := old.value
+		old.value = value
+		return prev, true
+	}
+
+	om.makeRoom()
+
+	item := &node[K]{value: key}
+	om.items.push(item)
+	om.data[key] = &element[K, V]{value, item}
+
+	var def V
+	return def, false
+}
+
+// With adds a key->value entry to a map the same way as Set does and returns the map itself,
+// which allows to chain calls:
+//
+//	om := New[string, int]().With("a", 1).With("b", 2)
+func (om *OrderedMap[K, V]) With(key K, value V) *OrderedMap[K, V] {
+	om.Set(key, value)
+	return om
+}
+
+// SetMany adds key->value entries to a map in the order they are passed.
+// Each entry is added the same way as by Set, i.e. existing keys are updated without changing their position.
+func (om *OrderedMap[K, V]) SetMany(pairs ...Pair[K, V]) {
+	for _, p := range pairs {
+		om.Set(p.Key, p.Value)
+	}
+}
+
+// Merge adds all key->value entries of `other` to a map in keys insertion order of `other`.
+// Each entry is added the same way as by Set: values of existing keys are overwritten
+// without changing their position, new keys are added to the end. `other` is not modified.
+//
+// Merging into an empty map copies `other` preserving its keys order.
+func (om *OrderedMap[K, V]) Merge(other *OrderedMap[K, V]) {
+	for curr := other.items.head; curr != nil; curr = curr.next {
+		om.Set(curr.value, other.data[curr.value].value)
+	}
+}
+
+// GetOrSet retrieves a value corresponding to `key` or adds a key->value entry to a map if `key` is absent.
+//
+// Parameters:
+//   - `key` - map entry key.
+//   - `value` - map entry value which is added if `key` is not present in a map.
+//
+// Returns:
+//   - (existing, true) if `key` already existed in a map, where `existing` is a current value of the entry;
+//   - (value, false) if `key` didn't exist before and a new entry was added to the end of a map.
+func (om *OrderedMap[K, V]) GetOrSet(key K, value V) (V, bool) {
+	if elem, ok := om.data[key]; ok {
+		return elem.value, true
+	}
+
+	om.Set(key, value)
+	return value, false
+}
+
+// SetIfAbsent adds a key->value entry to the end of a map only if `key` is not present in a map.
+// Unlike Set, it never overwrites a value of an existing entry.
+//
+// Returns:
+//   - true if a new entry was added;
+//   - false if `key` already existed in a map, in this case a map is not modified.
+func (om *OrderedMap[K, V]) SetIfAbsent(key K, value V) bool {
+	if om.Has(key) {
+		return false
+	}
+
+	om.Set(key, value)
+	return true
+}
+
+// Replace updates a value of an existing entry with a given `key`.
+// Unlike Set, it never adds a new entry to a map.
+//
+// Returns:
+//   - (old, true) if `key` is present in a map, where `old` is a previous value of the entry;
+//   - (<zero>, false) if `key` is not present in a map, in this case a map is not modified.
+func (om *OrderedMap[K, V]) Replace(key K, value V) (V, bool) {
+	if om.Has(key) {
+		return om.Set(key, value)
+	}
+
+	var def V
+	return def, false
+}
+
+// Update sets a value corresponding to `key` to the result of `fn` applied to the current value.
+//
+// If `key` is present in a map, `fn` is called with its current value and true,
+// the value is updated without changing position of `key` in the keys list.
+// Otherwise, `fn` is called with <zero> value of type V and false, and a new entry is added to the end of a map.
+//
+// Returns:
+//   - new value corresponding to `key`.
+func (om *OrderedMap[K, V]) Update(key K, fn func(old V, exists bool) V) V {
+	if elem, ok := om.data[key]; ok {
+		elem.value = fn(elem.value, true)
+		return elem.value
+	}
+
+	var def V
+	value := fn(def, false)
+	om.Set(key, value)
+
+	return value
+}
+
+// SetFront adds a key->value entry to the beginning of a map.
+//
+// It works the same way as Set, except that a new `key` is added to the beginning of the keys list
+// instead of the end. If `key` is already present in a map, corresponding entry is updated
+// with a new value and `key` is not moved.
+//
+// If a map was created with NewWithCapacity and is full, the oldest entry is evicted before a new `key` is added.
+// Since a new `key` becomes the first one in the keys list, it is the next entry to be evicted.
+//
+// Parameters:
+//   - `key` - map entry key.
+//   - `value` - map entry value.
+//
+// Returns:
+//   - (old, true) if `key` already existed in a map, where `old` is a previous value of the entry;
+//   - (<zero>, false) if `key` didn't exist before where <zero> represents a default value for type V.
+func (om *OrderedMap[K, V]) SetFront(key K, value V) (V, bool) {
+	if old, ok := om.data[key]; ok {
+		prev := old.value
+		old.value = value
+		return prev, true
+	}
+
+	om.makeRoom()
+
+	item := &node[K]{value: key}
+	om.items.pushFront(item)
+	om.data[key] = &element[K, V]{value, item}
+
+	var def V
+	return def, false
+}
+
+// InsertAfter adds a key->value entry to a map right after an entry with `anchor` key.
+//
+// If a map was created with NewWithCapacity and is full, the oldest entry is evicted before a new `key` is added.
+// If `anchor` is evicted this way, a new entry is added to the beginning of a map.
+//
+// Parameters:
+//   - `anchor` - a key of the entry after which a new entry is added.
+//   - `key` - map entry key.
+//   - `value` - map entry value.
+//
+// Returns:
+//   - true if a new entry was added;
+//   - false if `anchor` is not present in a map or `key` is already present in a map.
+func (om *OrderedMap[K, V]) InsertAfter(anchor, key K, value V) bool {
+	mark, ok := om.data[anchor]
+	if !ok || om.Has(key) {
+		return false
+	}
+
+	om.makeRoom()
+
+	item := &node[K]{value: key}
+	if om.Has(anchor) {
+		om.items.insertAfter(mark.item, item)
+	} else {
+		om.items.pushFront(item)
+	}
+	om.data[key] = &element[K, V]{value, item}
+
+	return true
+}
+
+// InsertBefore adds a key->value entry to a map right before an entry with `anchor` key.
+//
+// If a map was created with NewWithCapacity and is full, the oldest entry is evicted before a new `key` is added.
+// If `anchor` is evicted this way, a new entry is added to the beginning of a map.
+//
+// Parameters:
+//   - `anchor` - a key of the entry before which a new entry is added.
+//   - `key` - map entry key.
+//   - `value` - map entry value.
+//
+// Returns:
+//   - true if a new entry was added;
+//   - false if `anchor` is not present in a map or `key` is already present in a map.
+func (om *OrderedMap[K, V]) InsertBefore(anchor, key K, value V) bool {
+	mark, ok := om.data[anchor]
+	if !ok || om.Has(key) {
+		return false
+	}
+
+	om.makeRoom()
+
+	item := &node[K]{value: key}
+	if om.Has(anchor) {
+		om.items.insertBefore(mark.item, item)
+	} else {
+		om.items.pushFront(item)
+	}
+	om.data[key] = &element[K, V]{value, item}
+
+	return true
+}
+
+// InsertAt adds a key->value entry to a map at a given position in keys insertion order,
+// shifting entries at and after that position.
+//
+// If a map was created with NewWithCapacity and is full, the oldest entry is evicted before a new `key` is added.
+// In this case `index` refers to a position in a map after eviction, so a new entry still ends up at `index`,
+// except for `index` equal to Len() before eviction, which adds a new entry to the end of a map.
+//
+// NOTE: this operation takes linear time, because it requires walking the keys list.
+//
+// Parameters:
+//   - `index` - zero-based position of a new entry, 0 means the beginning of a map, Len() means the end of a map.
+//   - `key` - map entry key.
+//   - `value` - map entry value.
+//
+// Returns:
+//   - true if a new entry was added;
+//   - false if `index` is not within [0, Len()] range or `key` is already present in a map.
+func (om *OrderedMap[K, V]) InsertAt(index int, key K, value V) bool {
+	if index < 0 || index > om.Len() || om.Has(key) {
+		return false
+	}
+
+	om.makeRoom()
+
+	item := &node[K]{value: key}
+	if index = min(index, om.Len()); index == om.Len() {
+		om.items.push(item)
+	} else {
+		om.items.insertBefore(om.items.at(index), item)
+	}
+	om.data[key] = &element[K, V]{value, item}
+
+	return true
+}
+
+// Delete removes a key->value entry from a map.
+//
+// Parameters:
+//   - `key` - map entry key.
+//
+// Returns:
+//   - (value, true) if key->value entry was present in a map;
+//   - (<zero>, false) is returned otherwise where <zero> represents a default value for type V.
+func (om *OrderedMap[K, V]) Delete(key K) (V, bool) {
+	if val, ok := om.data[key]; ok {
+		om.items.remove(val.item)
+		delete(om.data, key)
+		return val.value, true
+	}
+
+	var val V
+	return val, false
+}
+
+// Rename changes a key of an entry from `oldKey` to `newKey`.
+// Both value and position of the entry in the keys list are preserved.
+//
+// Parameters:
+//   - `oldKey` - a key of the entry to rename.
+//   - `newKey` - a new key of the entry.
+//
+// Returns:
+//   - true if the entry was renamed;
+//   - false if `oldKey` is not present in a map or `newKey` is already present in a map.
+func (om *OrderedMap[K, V]) Rename(oldKey, newKey K) bool {
+	elem, ok := om.data[oldKey]
+	if !ok || om.Has(newKey) {
+		return false
+	}
+
+	elem.item.value = newKey
+	delete(om.data, oldKey)
+	om.data[newKey] = elem
+
+	return true
+}
+
+// Pop removes a key->value entry from a map and returns its value.
+//
+// It works exactly the same way as Delete and is provided for readability in code which takes entries
+// out of a map to use them. Unlike PopFront and PopBack, it removes an entry with a given key
+// regardless of its position.
+//
+// Parameters:
+//   - `key` - map entry key.
+//
+// Returns:
+//   - (value, true) if key->value entry was present in a map;
+//   - (<zero>, false) is returned otherwise where <zero> represents a default value for type V.
+func (om *OrderedMap[K, V]) Pop(key K) (V, bool) {
+	return om.Delete(key)
+}
+
+// DeleteMany removes key->value entries with given `keys` from a map.
+// Keys which are not present in a map are skipped.
+//
+// Returns:
+//   - number of entries actually removed.
+func (om *OrderedMap[K, V]) DeleteMany(keys ...K) int {
+	count := 0
+	for _, key := range keys {
+		if _, ok := om.Delete(key); ok {
+			count++
+		}
+	}
+
+	return count
+}
+
+// RemoveAt removes an entry at a given position in keys insertion order and returns it.
+//
+// NOTE: this operation takes linear time, because it requires walking the keys list.
+//
+// Parameters:
+//   - `index` - zero-based position of the entry.
+//
+// Returns:
+//   - (key, value, true) if `index` is within [0, Len()) range;
+//   - (<zero>, <zero>, false) otherwise, where <zero> represents a default value for types K and V.
+func (om *OrderedMap[K, V]) RemoveAt(index int) (K, V, bool) {
+	return om.pop(om.items.at(index))
+}
+
+// DeleteFunc removes all key->value entries for which `pred` returns true.
+// Order of the remaining entries is preserved.
+//
+// NOTE: if a map is modified by `pred`, the result is undefined.
+//
+// Returns:
+//   - number of entries removed.
+func (om *OrderedMap[K, V]) DeleteFunc(pred func(K, V) bool) int {
+	count := 0
+	for curr := om.items.head; curr != nil; {
+		next := curr.next
+		if pred(curr.value, om.data[curr.value].value) {
+			om.Delete(curr.value)
+			count++
+		}
+		curr = next
+	}
+
+	return count
+}
+
+// Retain removes all key->value entries for which `pred` returns false, i.e. it keeps only matching entries.
+// Unlike Filter, it modifies a map in place. Order of the remaining entries is preserved.
+//
+// NOTE: if a map is modified by `pred`, the result is undefined.
+//
+// Returns:
+//   - number of entries removed.
+func (om *OrderedMap[K, V]) Retain(pred func(K, V) bool) int {
+	return om.DeleteFunc(func(k K, v V) bool { return !pred(k, v) })
+}
+
+// DedupeValues removes key->value entries whose value is equal to a value of some preceding entry,
+// so only the first key is kept for each distinct value. Values are compared using `eq`.
+// Order of the remaining entries is preserved.
+//
+// NOTE: this operation takes quadratic time in the worst case,
+// because each value is compared with all distinct values seen so far.
+//
+// Returns:
+//   - number of entries removed.
+func (om *OrderedMap[K, V]) DedupeValues(eq func(a, b V) bool) int {
+	var seen []V
+	return om.DeleteFunc(func(_ K, v V) bool {
+		for _, s := range seen {
+			if eq(s, v) {
+				return true
+			}
+		}
+
+		seen = append(seen, v)
+		return false
+	})
+}
+
+// PopFront removes the first entry of a map (i.e. the entry with the oldest key) and returns it.
+//
+// Returns:
+//   - (key, value, true) if a map is not empty;
+//   - (<zero>, <zero>, false) otherwise, where <zero> represents a default value for types K and V.
+func (om *OrderedMap[K, V]) PopFront() (K, V, bool) {
+	return om.pop(om.items.head)
+}
+
+// KeepLast removes entries from the beginning of a map (i.e. the oldest ones) until at most `n` entries are left.
+// This allows to use a map as a sliding window over the most recent entries.
+// If `n` is negative, it is treated as 0.
+//
+// NOTE: unlike eviction in a map created with NewWithCapacity, removed entries are not reported to OnEvict.
+//
+// Returns:
+//   - number of entries removed.
+func (om *OrderedMap[K, V]) KeepLast(n int) int {
+	count := 0
+	for om.Len() > max(n, 0) {
+		om.PopFront()
+		count++
+	}
+
+	return count
+}
+
+// PopBack removes the last entry of a map (i.e. the entry with the most recently inserted key) and returns it.
+//
+// Returns:
+//   - (key, value, true) if a map is not empty;
+//   - (<zero>, <zero>, false) otherwise, where <zero> represents a default value for types K and V.
+func (om *OrderedMap[K, V]) PopBack() (K, V, bool) {
+	return om.pop(om.items.tail)
+}
+
+// pop removes an entry corresponding to a list node `n` and returns it.
+func (om *OrderedMap[K, V]) pop(n *node[K]) (K, V, bool) {
+	key, val, ok := om.entry(n)
+	if ok {
+		om.items.remove(n)
+		delete(om.data, key)
+	}
+
+	return key, val, ok
+}
+
+// Clear removes all entries from a map.
+func (om *OrderedMap[K, V]) Clear() {
+	om.data = make(map[K]*element[K, V])
+	om.items = &list[K]{}
+}
+
+// Equal reports whether two maps contain the same key->value entries in the same keys order.
+//
+// Parameters:
+//   - `other` - a map to compare with.
+//   - `eq` - a function which reports whether two values are equal.
+func (om *OrderedMap[K, V]) Equal(other *OrderedMap[K, V], eq func(a, b V) bool) bool {
+	if om.Len() != other.Len() {
+		return false
+	}
+
+	for a, b := om.items.head, other.items.head; a != nil; a, b = a.next, b.next {
+		if a.value != b.value || !eq(om.data[a.value].value, other.data[b.value].value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SameOrder reports whether a map and `other` contain the same keys in the same order. Values are ignored.
+func (om *OrderedMap[K, V]) SameOrder(other *OrderedMap[K, V]) bool {
+	return om.Equal(other, func(V, V) bool { return true })
+}
+
+// Hash computes a fingerprint of map contents which depends on both entries and their order.
+//
+// Each key and value is hashed using `hashKey` and `hashVal` respectively,
+// resulting numbers are folded in keys insertion order as little-endian bytes using 64-bit FNV-1a.
+// Maps with the same entries in the same order have equal hashes,
+// maps with the same entries in a different order very likely have different hashes.
+// The result is deterministic as long as `hashKey` and `hashVal` are.
+func (om *OrderedMap[K, V]) Hash(hashKey func(K) uint64, hashVal func(V) uint64) uint64 {
+	const (
+		offset64 = 14695981039346656037
+		prime64  = 1099511628211
+	)
+
+	h := uint64(offset64)
+	mix := func(x uint64) {
+		for i := 0; i < 8; i++ {
+			h ^= x & 0xff
+			h *= prime64
+			x >>= 8
+		}
+	}
+
+	for curr := om.items.head; curr != nil; curr = curr.next {
+		mix(hashKey(curr.value))
+		mix(hashVal(om.data[curr.value].value))
+	}
+
+	return h
+}
+
+// Diff compares a map with `other` and reports which keys differ.
+//
+// Parameters:
+//   - `other` - a map to compare with.
+//   - `eq` - a function which reports whether two values are equal.
+//
+// Returns:
+//   - `added` - keys present only in `other`, in keys insertion order of `other`;
+//   - `removed` - keys present only in a map, in keys insertion order of a map;
+//   - `changed` - keys present in both maps with different values, in keys insertion order of a map.
+func (om *OrderedMap[K, V]) Diff(other *OrderedMap[K, V], eq func(a, b V) bool) (added, removed, changed []K) {
+	added, removed, changed = []K{}, []K{}, []K{}
+
+	for curr := om.items.head; curr != nil; curr = curr.next {
+		if elem, ok := other.data[curr.value]; !ok {
+			removed = append(removed, curr.value)
+		} else if !eq(om.data[curr.value].value, elem.value) {
+			changed = append(changed, curr.value)
+		}
+	}
+
+	for curr := other.items.head; curr != nil; curr = curr.next {
+		if !om.Has(curr.value) {
+			added = append(added, curr.value)
+		}
+	}
+
+	return added, removed, changed
 }
 
-// New creates a new instance of OrderedMap and returns a pointer to it.
-func New[K comparable, V any]() *OrderedMap[K, V] {
-	return &OrderedMap[K, V]{
-		data:  make(map[K]*element[K, V]),
-		items: &list[K]{},
+// Clone returns a shallow copy of a map.
+//
+// Keys and values are copied by assignment, insertion order of keys is preserved.
+// A clone of a bounded map has the same capacity, but eviction callback is not copied.
+func (om *OrderedMap[K, V]) Clone() *OrderedMap[K, V] {
+	clone := &OrderedMap[K, V]{
+		data:     make(map[K]*element[K, V], om.Len()),
+		items:    &list[K]{},
+		capacity: om.capacity,
+	}
+
+	for curr := om.items.head; curr != nil; curr = curr.next {
+		clone.Set(curr.value, om.data[curr.value].value)
 	}
+
+	return clone
 }
 
-// Get retrieves a value corresponding to `key`.
+// MoveToFront moves an entry with a given `key` to the beginning of the keys list.
+// A value of the entry is not changed.
 //
 // Parameters:
-//   - `key` - a key in the map.
+//   - `key` - map entry key.
 //
 // Returns:
-//   - (value, true) if corresponding key->value pair is present in a map;
-//   - (<zero>, false) is returned otherwise, where <zero> represents a default value for type V.
-func (om *OrderedMap[K, V]) Get(key K) (V, bool) {
-	if elem, ok := om.data[key]; ok {
-		return elem.value, true
+//   - true if `key` is present in a map;
+//   - false otherwise.
+func (om *OrderedMap[K, V]) MoveToFront(key K) bool {
+	elem, ok := om.data[key]
+	if !ok {
+		return false
 	}
 
-	var def V
-	return def, false
+	om.items.remove(elem.item)
+	om.items.pushFront(elem.item)
+
+	return true
 }
 
-// Set adds a key->value entry to a map.
-//
-// If `key` is already present in a map, corresponding entry is updated with a new value.
-// In this case insertion order of keys is not changed (i.e. `key` is not moved to the end of the keys list).
+// MoveToBack moves an entry with a given `key` to the end of the keys list.
+// A value of the entry is not changed.
 //
 // Parameters:
 //   - `key` - map entry key.
-//   - `value` - map entry value.
 //
 // Returns:
-//   - (old, true) if `key` already existed in a map, where `old` is a previous value of the entry;
-//   - (<zero>, false) if `key` didn't exist before where <zero> represents a default value for type V.
-func (om *OrderedMap[K, V]) Set(key K, value V) (V, bool) {
-	if old, ok := om.data[key]; ok {
-		om.data[key].value = value
-		return old.value, true
+//   - true if `key` is present in a map;
+//   - false otherwise.
+func (om *OrderedMap[K, V]) MoveToBack(key K) bool {
+	elem, ok := om.data[key]
+	if !ok {
+		return false
 	}
 
-	item := &node[K]{value: key}
-	om.items.push(item)
-	om.data[key] = &element[K, V]{value, item}
+	om.items.remove(elem.item)
+	om.items.push(elem.item)
 
-	var def V
-	return def, false
+	return true
 }
 
-// Delete removes a key->value entry from a map.
+// lazyInit initializes a zero value of OrderedMap, so that it can be used by decoders.
+func (om *OrderedMap[K, V]) lazyInit() {
+	if om.data == nil {
+		om.data = make(map[K]*element[K, V])
+		om.items = &list[K]{}
+	}
+}
+
+// first returns the first node of the keys list or nil if a map is empty.
+// Unlike accessing om.items directly, it treats a zero value of OrderedMap as an empty map, so that it can be used by encoders.
+func (om *OrderedMap[K, V]) first() *node[K] {
+	if om.items == nil {
+		return nil
+	}
+
+	return om.items.head
+}
+
+// MoveBefore moves an entry with a given `key` right before an entry with `anchor` key.
+// A value of the entry is not changed.
 //
 // Parameters:
-//   - `key` - map entry key.
+//   - `key` - a key of the entry to move.
+//   - `anchor` - a key of the entry before which the entry is moved.
 //
 // Returns:
-//   - (value, true) if key->value entry was present in a map;
-//   - (<zero>, false) is returned otherwise where <zero> represents a default value for type V.
-func (om *OrderedMap[K, V]) Delete(key K) (V, bool) {
-	if val, ok := om.data[key]; ok {
-		om.items.remove(val.item)
-		delete(om.data, key)
-		return val.value, true
+//   - true if the entry was moved;
+//   - false if either `key` or `anchor` is not present in a map or they are equal.
+func (om *OrderedMap[K, V]) MoveBefore(key, anchor K) bool {
+	elem, mark, ok := om.movable(key, anchor)
+	if !ok {
+		return false
 	}
 
-	var val V
-	return val, false
+	om.items.remove(elem.item)
+	om.items.insertBefore(mark.item, elem.item)
+
+	return true
+}
+
+// MoveAfter moves an entry with a given `key` right after an entry with `anchor` key.
+// A value of the entry is not changed.
+//
+// Parameters:
+//   - `key` - a key of the entry to move.
+//   - `anchor` - a key of the entry after which the entry is moved.
+//
+// Returns:
+//   - true if the entry was moved;
+//   - false if either `key` or `anchor` is not present in a map or they are equal.
+func (om *OrderedMap[K, V]) MoveAfter(key, anchor K) bool {
+	elem, mark, ok := om.movable(key, anchor)
+	if !ok {
+		return false
+	}
+
+	om.items.remove(elem.item)
+	om.items.insertAfter(mark.item, elem.item)
+
+	return true
+}
+
+// Swap exchanges positions of entries with keys `a` and `b` in the keys list.
+// Values of the entries are not changed.
+//
+// Returns:
+//   - true if both `a` and `b` are present in a map (swapping a key with itself is a no-op);
+//   - false otherwise.
+func (om *OrderedMap[K, V]) Swap(a, b K) bool {
+	ea, ok := om.data[a]
+	if !ok {
+		return false
+	}
+
+	eb, ok := om.data[b]
+	if !ok {
+		return false
+	}
+
+	ea.item.value, eb.item.value = b, a
+	ea.item, eb.item = eb.item, ea.item
+
+	return true
+}
+
+// movable returns entries corresponding to `key` and `anchor` if `key` can be moved relative to `anchor`.
+func (om *OrderedMap[K, V]) movable(key, anchor K) (*element[K, V], *element[K, V], bool) {
+	if key == anchor {
+		return nil, nil, false
+	}
+
+	elem, ok := om.data[key]
+	if !ok {
+		return nil, nil, false
+	}
+
+	mark, ok := om.data[anchor]
+	if !ok {
+		return nil, nil, false
+	}
+
+	return elem, mark, true
+}
+
+// validate checks consistency of internal data structures of a map and returns an error describing
+// the first violated invariant. It is intended to be used in tests.
+func (om *OrderedMap[K, V]) validate() error {
+	if (om.items.head == nil) != (om.items.tail == nil) {
+		return errors.New("either head or tail is nil, but not both")
+	}
+
+	if om.items.head != nil && om.items.head.prev != nil {
+		return errors.New("head has previous node")
+	}
+
+	if om.items.tail != nil && om.items.tail.next != nil {
+		return errors.New("tail has next node")
+	}
+
+	count := 0
+	var prev *node[K]
+	for curr := om.items.head; curr != nil; prev, curr = curr, curr.next {
+		if count++; count > om.Len() {
+			return fmt.Errorf("list has more nodes than map has entries (%d)", om.Len())
+		}
+
+		if curr.prev != prev {
+			return fmt.Errorf("node %v has invalid previous node", curr.value)
+		}
+
+		if elem, ok := om.data[curr.value]; !ok || elem.item != curr {
+			return fmt.Errorf("node %v doesn't match map entry", curr.value)
+		}
+	}
+
+	if prev != om.items.tail {
+		return errors.New("tail is not reachable from head")
+	}
+
+	if count != om.Len() {
+		return fmt.Errorf("list has %d nodes, but map has %d entries", count, om.Len())
+	}
+
+	return nil
+}
+
+// makeRoom evicts the oldest entry if a map is bounded and full.
+func (om *OrderedMap[K, V]) makeRoom() {
+	if om.capacity == 0 || om.Len() < om.capacity {
+		return
+	}
+
+	key := om.items.head.value
+	val, _ := om.Delete(key)
+
+	if om.onEvict != nil {
+		om.onEvict(key, val)
+	}
+}
+
+// entry returns a key and a value corresponding to a list node `n`.
+func (om *OrderedMap[K, V]) entry(n *node[K]) (K, V, bool) {
+	if n == nil {
+		var key K
+		var val V
+		return key, val, false
+	}
+
+	return n.value, om.data[n.value].value, true
 }
 
 // Len returns total number of elements in a map.
@@ -85,6 +1019,131 @@ func (om *OrderedMap[K, V]) Len() int {
 	return len(om.data)
 }
 
+// Reserve makes sure that a map can hold at least `n` elements without reallocating its internal storage.
+// This is useful before adding a large number of entries. Keys order is not changed.
+func (om *OrderedMap[K, V]) Reserve(n int) {
+	if n <= om.Len() {
+		return
+	}
+
+	data := make(map[K]*element[K, V], n)
+	for key, elem := range om.data {
+		data[key] = elem
+	}
+
+	om.data = data
+}
+
+// Compact reallocates internal storage of a map to fit its current number of elements.
+// This allows to release memory after a large number of entries was removed from a map.
+// Keys order is not changed.
+func (om *OrderedMap[K, V]) Compact() {
+	data := make(map[K]*element[K, V], om.Len())
+	for curr := om.items.head; curr != nil; curr = curr.next {
+		data[curr.value] = om.data[curr.value]
+	}
+
+	om.data = data
+}
+
+// Cap returns maximum number of elements in a map created with NewWithCapacity.
+// For an unbounded map (i.e. a map created with New) Cap returns 0.
+func (om *OrderedMap[K, V]) Cap() int {
+	return om.capacity
+}
+
+// Keys returns a slice of all keys of a map in keys insertion order.
+//
+// The returned slice is a copy, so it can be safely modified by the caller.
+// For an empty map an empty non-nil slice is returned.
+func (om *OrderedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, om.Len())
+	for curr := om.first(); curr != nil; curr = curr.next {
+		keys = append(keys, curr.value)
+	}
+
+	return keys
+}
+
+// Values returns a slice of all values of a map in keys insertion order.
+//
+// The returned slice is a copy, so it can be safely modified by the caller.
+// For an empty map an empty non-nil slice is returned.
+func (om *OrderedMap[K, V]) Values() []V {
+	values := make([]V, 0, om.Len())
+	for curr := om.first(); curr != nil; curr = curr.next {
+		values = append(values, om.data[curr.value].value)
+	}
+
+	return values
+}
+
+// Pairs returns a slice of all key->value entries of a map in keys insertion order.
+//
+// The returned slice is a copy, so it can be safely modified by the caller.
+// For an empty map an empty non-nil slice is returned.
+func (om *OrderedMap[K, V]) Pairs() []Pair[K, V] {
+	pairs := make([]Pair[K, V], 0, om.Len())
+	for curr := om.items.head; curr != nil; curr = curr.next {
+		pairs = append(pairs, Pair[K, V]{curr.value, om.data[curr.value].value})
+	}
+
+	return pairs
+}
+
+// ToMap returns a regular Go map with all key->value entries of a map.
+//
+// NOTE: keys order is not preserved in the returned map.
+func (om *OrderedMap[K, V]) ToMap() map[K]V {
+	res := make(map[K]V, om.Len())
+	for key, elem := range om.data {
+		res[key] = elem.value
+	}
+
+	return res
+}
+
+// ForEach calls `fn` for each key->value entry of a map in keys insertion order.
+// Iteration stops as soon as `fn` returns false.
+//
+// NOTE: if a map is modified by `fn`, the result is undefined.
+func (om *OrderedMap[K, V]) ForEach(fn func(K, V) bool) {
+	for curr := om.items.head; curr != nil; curr = curr.next {
+		if !fn(curr.value, om.data[curr.value].value) {
+			return
+		}
+	}
+}
+
+// Apply replaces a value of each entry with the result of `fn` applied to the entry.
+// Entries are visited in keys insertion order, keys and their order are not changed.
+//
+// NOTE: if a map is modified by `fn`, the result is undefined.
+func (om *OrderedMap[K, V]) Apply(fn func(K, V) V) {
+	for curr := om.items.head; curr != nil; curr = curr.next {
+		elem := om.data[curr.value]
+		elem.value = fn(curr.value, elem.value)
+	}
+}
+
+// String returns a string representation of a map in the form of `OrderedMap[k1:v1, k2:v2]`,
+// where entries are listed in keys insertion order.
+func (om *OrderedMap[K, V]) String() string {
+	var sb strings.Builder
+	sb.WriteString("OrderedMap[")
+
+	for curr := om.items.head; curr != nil; curr = curr.next {
+		if curr != om.items.head {
+			sb.WriteString(", ")
+		}
+
+		fmt.Fprintf(&sb, "%v:%v", curr.value, om.data[curr.value].value)
+	}
+
+	sb.WriteString("]")
+	return sb.String()
+}
+
 // Iterator returns a function which can be used to iterate over key->value pairs of a map
 // in keys insertion order.
 //
@@ -102,7 +1161,49 @@ func (om *OrderedMap[K, V]) Len() int {
 // NOTE: if a map is modified when iteration is in progress,
 // the result of a subsequent call to next() is undefined.
 func (om *OrderedMap[K, V]) Iterator() func() (K, V, bool) {
-	curr := om.items.head
+	return om.iterator(om.items.head)
+}
+
+// IteratorFrom returns a function which can be used to iterate over key->value pairs of a map
+// in keys insertion order starting from `key` (inclusive). See Iterator for details.
+//
+// Returns:
+//   - (next, true) if `key` is present in a map;
+//   - (next, false) otherwise, in this case the first call to next() reports that there are no elements left.
+func (om *OrderedMap[K, V]) IteratorFrom(key K) (func() (K, V, bool), bool) {
+	elem, ok := om.data[key]
+	if !ok {
+		return om.iterator(nil), false
+	}
+
+	return om.iterator(elem.item), true
+}
+
+// IteratorIndexed returns a function which can be used to iterate over key->value pairs of a map
+// in keys insertion order along with their zero-based positions. See Iterator for details.
+//
+// Example:
+//
+//	next := om.IteratorIndexed()
+//	for i, k, v, ok := next(); ok; i, k, v, ok = next() {
+//	  fmt.Printf("%d. key: %v, value: %v", i+1, k, v)
+//	}
+func (om *OrderedMap[K, V]) IteratorIndexed() func() (int, K, V, bool) {
+	index := 0
+	next := om.Iterator()
+	return func() (int, K, V, bool) {
+		key, val, ok := next()
+		if !ok {
+			return 0, key, val, false
+		}
+
+		index++
+		return index - 1, key, val, true
+	}
+}
+
+// iterator returns a function which iterates over key->value pairs of a map starting from a list node `curr`.
+func (om *OrderedMap[K, V]) iterator(curr *node[K]) func() (K, V, bool) {
 	return func() (K, V, bool) {
 		if curr == nil {
 			var key K
@@ -118,6 +1219,91 @@ func (om *OrderedMap[K, V]) Iterator() func() (K, V, bool) {
 	}
 }
 
+// ReverseIterator returns a function which can be used to iterate over key->value pairs of a map
+// in reverse keys insertion order (i.e. from the most recently inserted key to the oldest one).
+//
+// Example:
+//
+//	next := om.ReverseIterator()
+//	for k, v, ok := next(); ok; k, v, ok = next() {
+//	  fmt.Printf("key: %v, value: %v", k, v)
+//	}
+//
+// Function next() returns 3 values: key, value and a bool flag which indicates
+// if there are any unvisited elements left.
+//
+// NOTE: if a map is modified when iteration is in progress,
+// the result of a subsequent call to next() is undefined.
+func (om *OrderedMap[K, V]) ReverseIterator() func() (K, V, bool) {
+	curr := om.items.tail
+	return func() (K, V, bool) {
+		if curr == nil {
+			var key K
+			var val V
+			return key, val, false
+		}
+
+		key := curr.value
+		val := om.data[key].value
+		curr = curr.prev
+
+		return key, val, true
+	}
+}
+
+// InterleaveIterator returns a function which can be used to iterate over key->value pairs of a map
+// taking them alternately from the beginning and from the end of a map: the first entry, the last entry,
+// the second entry, the second to last entry and so on, until every entry is visited exactly once.
+// See Iterator for details.
+func (om *OrderedMap[K, V]) InterleaveIterator() func() (K, V, bool) {
+	front, back := om.items.head, om.items.tail
+	left, fromFront := om.Len(), true
+
+	return func() (K, V, bool) {
+		if left == 0 {
+			return om.entry(nil)
+		}
+
+		curr := back
+		if fromFront {
+			curr, front = front, front.next
+		} else {
+			back = back.prev
+		}
+
+		left--
+		fromFront = !fromFront
+
+		return om.entry(curr)
+	}
+}
+
+// SnapshotIterator returns a function which can be used to iterate over key->value pairs of a map
+// in keys insertion order, the same way as Iterator does.
+//
+// Unlike Iterator, it is safe to modify a map when iteration is in progress:
+// keys are captured when SnapshotIterator is called, while values are retrieved on each call to next().
+// Keys deleted after SnapshotIterator was called are skipped, keys added after that are not visited.
+//
+// NOTE: capturing keys takes linear time and memory.
+func (om *OrderedMap[K, V]) SnapshotIterator() func() (K, V, bool) {
+	keys := om.Keys()
+	return func() (K, V, bool) {
+		for len(keys) > 0 {
+			key := keys[0]
+			keys = keys[1:]
+
+			if elem, ok := om.data[key]; ok {
+				return key, elem.value, true
+			}
+		}
+
+		var key K
+		var val V
+		return key, val, false
+	}
+}
+
 type node[T any] struct {
 	value      T
 	prev, next *node[T]
@@ -132,6 +1318,19 @@ type list[T any] struct {
 	head, tail *node[T]
 }
 
+func (lst *list[T]) at(index int) *node[T] {
+	if index < 0 {
+		return nil
+	}
+
+	curr := lst.head
+	for ; curr != nil && index > 0; index-- {
+		curr = curr.next
+	}
+
+	return curr
+}
+
 func (lst *list[T]) push(n *node[T]) {
 	if lst.head == nil {
 		lst.head = n
@@ -143,6 +1342,84 @@ func (lst *list[T]) push(n *node[T]) {
 	}
 }
 
+func (lst *list[T]) pushFront(n *node[T]) {
+	if lst.head == nil {
+		lst.head = n
+		lst.tail = n
+	} else {
+		lst.head.prev = n
+		n.next = lst.head
+		lst.head = n
+	}
+}
+
+func (lst *list[T]) insertAfter(mark, n *node[T]) {
+	if mark == lst.tail {
+		lst.push(n)
+		return
+	}
+
+	n.prev = mark
+	n.next = mark.next
+	mark.next.prev = n
+	mark.next = n
+}
+
+func (lst *list[T]) insertBefore(mark, n *node[T]) {
+	if mark == lst.head {
+		lst.pushFront(n)
+		return
+	}
+
+	n.next = mark
+	n.prev = mark.prev
+	mark.prev.next = n
+	mark.prev = n
+}
+
+func (lst *list[T]) nodes() []*node[T] {
+	var res []*node[T]
+	for curr := lst.head; curr != nil; curr = curr.next {
+		res = append(res, curr)
+	}
+
+	return res
+}
+
+func (lst *list[T]) relink(nodes []*node[T]) {
+	lst.head = nil
+	lst.tail = nil
+
+	for _, n := range nodes {
+		n.prev = nil
+		n.next = nil
+		lst.push(n)
+	}
+}
+
+func (lst *list[T]) reverse() {
+	for curr := lst.head; curr != nil; curr = curr.prev {
+		curr.prev, curr.next = curr.next, curr.prev
+	}
+
+	lst.head, lst.tail = lst.tail, lst.head
+}
+
+func (lst *list[T]) rotate(head *node[T]) {
+	if head == lst.head {
+		return
+	}
+
+	lst.tail.next = lst.head
+	lst.head.prev = lst.tail
+
+	lst.head = head
+	lst.tail = head.prev
+
+	lst.head.prev = nil
+	lst.tail.next = nil
+}
+
 func (lst *list[T]) remove(n *node[T]) {
 	if n.prev != nil {
 		n.prev.next = n.next
@@ -159,4 +1436,7 @@ func (lst *list[T]) remove(n *node[T]) {
 	if n == lst.tail {
 		lst.tail = n.prev
 	}
+
+	n.prev = nil
+	n.next = nil
 }
@@ -1,6 +1,10 @@
 package orderedmap
 
 import (
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -70,3 +74,2005 @@ func TestOrderedMap(t *testing.T) {
 		t.Fatalf("value with key %q was not deleted as expected", expectedKeys[0])
 	}
 }
+
+func TestOrderedMapReverseIterator(t *testing.T) {
+	om := New[string, int]()
+
+	if _, _, ok := om.ReverseIterator()(); ok {
+		t.Fatalf("reverse iterator over empty map should not yield anything")
+	}
+
+	keys := []string{"d", "b", "c", "a"}
+	for i, k := range keys {
+		om.Set(k, i)
+	}
+
+	i := len(keys) - 1
+	next := om.ReverseIterator()
+	for k, v, ok := next(); ok; k, v, ok = next() {
+		if i < 0 {
+			t.Fatalf("reverse iterator yielded more than %d elements", len(keys))
+		}
+
+		if k != keys[i] || v != i {
+			t.Fatalf("wanted: (%q, %d), got: (%q, %d)", keys[i], i, k, v)
+		}
+		i--
+	}
+
+	if i != -1 {
+		t.Fatalf("reverse iterator stopped early, %d elements left", i+1)
+	}
+}
+
+func TestOrderedMapKeys(t *testing.T) {
+	om := New[string, int]()
+
+	if keys := om.Keys(); keys == nil || len(keys) != 0 {
+		t.Fatalf("wanted: empty non-nil slice, got: %#v", keys)
+	}
+
+	expected := []string{"d", "b", "c", "a"}
+	for i, k := range expected {
+		om.Set(k, i)
+	}
+
+	keys := om.Keys()
+	if !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, keys)
+	}
+
+	keys[0] = "modified"
+	if actual := om.Keys(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("modifying returned slice should not affect a map, wanted: %q, got: %q", expected, actual)
+	}
+
+	if val, ok := om.Get("d"); !ok || val != 0 {
+		t.Fatalf("get value, wanted: %d, got: %d", 0, val)
+	}
+}
+
+func TestOrderedMapValues(t *testing.T) {
+	om := New[string, int]()
+
+	if values := om.Values(); values == nil || len(values) != 0 {
+		t.Fatalf("wanted: empty non-nil slice, got: %#v", values)
+	}
+
+	om.Set("d", 3)
+	om.Set("b", 8)
+	om.Set("c", 5)
+	om.Set("a", 9)
+
+	var expected []int
+	next := om.Iterator()
+	for _, v, ok := next(); ok; _, v, ok = next() {
+		expected = append(expected, v)
+	}
+
+	if values := om.Values(); !reflect.DeepEqual(values, expected) {
+		t.Fatalf("wanted: %d, got: %d", expected, values)
+	}
+}
+
+func TestOrderedMapClear(t *testing.T) {
+	om := New[string, int]()
+	om.Set("d", 3)
+	om.Set("b", 8)
+	om.Set("c", 5)
+
+	om.Clear()
+
+	if om.Len() != 0 {
+		t.Fatalf("wanted: %d, got: %d", 0, om.Len())
+	}
+
+	if _, _, ok := om.Iterator()(); ok {
+		t.Fatalf("iterator over cleared map should not yield anything")
+	}
+
+	if _, ok := om.Get("d"); ok {
+		t.Fatalf("element with key %q should not exist after clear", "d")
+	}
+
+	om.Set("c", 1)
+	om.Set("a", 2)
+
+	expected := []string{"c", "a"}
+	if keys := om.Keys(); !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, keys)
+	}
+}
+
+func TestOrderedMapHas(t *testing.T) {
+	om := New[string, int]()
+
+	if om.Has("missing") {
+		t.Fatalf("element with key %q should not exist", "missing")
+	}
+
+	om.Set("a", 0)
+	om.Set("b", 1)
+
+	if !om.Has("a") || !om.Has("b") {
+		t.Fatalf("elements with keys %q and %q should exist", "a", "b")
+	}
+
+	if om.Has("missing") {
+		t.Fatalf("element with key %q should not exist", "missing")
+	}
+
+	om.Delete("a")
+
+	if om.Has("a") {
+		t.Fatalf("element with key %q was not deleted as expected", "a")
+	}
+}
+
+func TestOrderedMapClone(t *testing.T) {
+	om := New[string, int]()
+	om.Set("d", 3)
+	om.Set("b", 8)
+	om.Set("c", 5)
+
+	clone := om.Clone()
+
+	expected := []string{"d", "b", "c"}
+	if keys := clone.Keys(); !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, keys)
+	}
+
+	if values := clone.Values(); !reflect.DeepEqual(values, om.Values()) {
+		t.Fatalf("wanted: %d, got: %d", om.Values(), values)
+	}
+
+	clone.Delete("d")
+	clone.Set("b", 0)
+	clone.Set("a", 9)
+	om.Delete("c")
+
+	if keys := om.Keys(); !reflect.DeepEqual(keys, []string{"d", "b"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"d", "b"}, keys)
+	}
+
+	if val, _ := om.Get("b"); val != 8 {
+		t.Fatalf("get value, wanted: %d, got: %d", 8, val)
+	}
+
+	if keys := clone.Keys(); !reflect.DeepEqual(keys, []string{"b", "c", "a"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"b", "c", "a"}, keys)
+	}
+}
+
+func TestOrderedMapMoveToFrontAndBack(t *testing.T) {
+	om := New[string, int]()
+	om.Set("a", 0)
+	om.Set("b", 1)
+	om.Set("c", 2)
+	om.Set("d", 3)
+
+	tests := []struct {
+		move     func(string) bool
+		key      string
+		expected []string
+	}{
+		{om.MoveToFront, "c", []string{"c", "a", "b", "d"}},
+		{om.MoveToFront, "c", []string{"c", "a", "b", "d"}},
+		{om.MoveToBack, "a", []string{"c", "b", "d", "a"}},
+		{om.MoveToBack, "a", []string{"c", "b", "d", "a"}},
+		{om.MoveToFront, "a", []string{"a", "c", "b", "d"}},
+		{om.MoveToBack, "a", []string{"c", "b", "d", "a"}},
+	}
+
+	for _, test := range tests {
+		if !test.move(test.key) {
+			t.Fatalf("element with key %q should exist", test.key)
+		}
+
+		if keys := om.Keys(); !reflect.DeepEqual(keys, test.expected) {
+			t.Fatalf("wanted: %q, got: %q", test.expected, keys)
+		}
+
+		if keys := reverseKeys(om); !reflect.DeepEqual(keys, reversed(test.expected)) {
+			t.Fatalf("wanted: %q, got: %q", reversed(test.expected), keys)
+		}
+	}
+
+	if val, _ := om.Get("a"); val != 0 {
+		t.Fatalf("get value, wanted: %d, got: %d", 0, val)
+	}
+
+	if om.MoveToFront("missing") || om.MoveToBack("missing") {
+		t.Fatalf("element with key %q doesn't exist, it cannot be moved", "missing")
+	}
+}
+
+func reverseKeys[K comparable, V any](om *OrderedMap[K, V]) []K {
+	keys := make([]K, 0, om.Len())
+	next := om.ReverseIterator()
+	for k, _, ok := next(); ok; k, _, ok = next() {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+func reversed[T any](s []T) []T {
+	r := make([]T, 0, len(s))
+	for i := len(s) - 1; i >= 0; i-- {
+		r = append(r, s[i])
+	}
+
+	return r
+}
+
+func TestOrderedMapGetLRU(t *testing.T) {
+	om := New[string, int]()
+	om.Set("a", 0)
+	om.Set("b", 1)
+	om.Set("c", 2)
+
+	if val, ok := om.GetLRU("b"); !ok || val != 1 {
+		t.Fatalf("get value, wanted: %d, got: %d", 1, val)
+	}
+
+	expected := []string{"a", "c", "b"}
+	if keys := om.Keys(); !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, keys)
+	}
+
+	if val, ok := om.GetLRU("missing"); ok || val != 0 {
+		t.Fatalf("element with key %q should not exist", "missing")
+	}
+
+	if keys := om.Keys(); !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, keys)
+	}
+}
+
+func TestOrderedMapWithCapacity(t *testing.T) {
+	type entry struct {
+		key string
+		val int
+	}
+
+	var evicted []entry
+
+	om := NewWithCapacity[string, int](3)
+	om.OnEvict(func(k string, v int) {
+		evicted = append(evicted, entry{k, v})
+	})
+
+	om.Set("a", 0)
+	om.Set("b", 1)
+	om.Set("c", 2)
+
+	if len(evicted) != 0 {
+		t.Fatalf("nothing should be evicted until a map is full, got: %v", evicted)
+	}
+
+	om.Set("a", 10)
+
+	if len(evicted) != 0 {
+		t.Fatalf("updating existing key should not cause eviction, got: %v", evicted)
+	}
+
+	om.Set("d", 3)
+	om.Set("e", 4)
+
+	expectedEvicted := []entry{{"a", 10}, {"b", 1}}
+	if !reflect.DeepEqual(evicted, expectedEvicted) {
+		t.Fatalf("wanted: %v, got: %v", expectedEvicted, evicted)
+	}
+
+	expected := []string{"c", "d", "e"}
+	if keys := om.Keys(); !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, keys)
+	}
+
+	if om.Len() != 3 {
+		t.Fatalf("wanted: %d, got: %d", 3, om.Len())
+	}
+}
+
+func TestOrderedMapWithCapacityOne(t *testing.T) {
+	om := NewWithCapacity[string, int](1)
+
+	om.Set("a", 0)
+	om.Set("a", 1)
+	om.Set("b", 2)
+
+	if keys := om.Keys(); !reflect.DeepEqual(keys, []string{"b"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"b"}, keys)
+	}
+
+	if val, ok := om.Get("b"); !ok || val != 2 {
+		t.Fatalf("get value, wanted: %d, got: %d", 2, val)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("creating a map with non-positive capacity should panic")
+		}
+	}()
+
+	NewWithCapacity[string, int](0)
+}
+
+func TestOrderedMapFrontAndBack(t *testing.T) {
+	om := New[string, int]()
+
+	if k, v, ok := om.Front(); ok || k != "" || v != 0 {
+		t.Fatalf("it should not be possible to get front element of empty map")
+	}
+
+	if k, v, ok := om.Back(); ok || k != "" || v != 0 {
+		t.Fatalf("it should not be possible to get back element of empty map")
+	}
+
+	om.Set("a", 0)
+
+	if k, v, ok := om.Front(); !ok || k != "a" || v != 0 {
+		t.Fatalf("front, wanted: (%q, %d), got: (%q, %d)", "a", 0, k, v)
+	}
+
+	if k, v, ok := om.Back(); !ok || k != "a" || v != 0 {
+		t.Fatalf("back, wanted: (%q, %d), got: (%q, %d)", "a", 0, k, v)
+	}
+
+	om.Set("b", 1)
+	om.Set("c", 2)
+	om.Set("d", 3)
+	om.Delete("a")
+	om.Delete("d")
+
+	if k, v, ok := om.Front(); !ok || k != "b" || v != 1 {
+		t.Fatalf("front, wanted: (%q, %d), got: (%q, %d)", "b", 1, k, v)
+	}
+
+	if k, v, ok := om.Back(); !ok || k != "c" || v != 2 {
+		t.Fatalf("back, wanted: (%q, %d), got: (%q, %d)", "c", 2, k, v)
+	}
+}
+
+func TestOrderedMapPopFrontAndBack(t *testing.T) {
+	keys := []string{"d", "b", "c", "a"}
+
+	om := New[string, int]()
+
+	if _, _, ok := om.PopFront(); ok {
+		t.Fatalf("it should not be possible to pop anything from empty map")
+	}
+
+	if _, _, ok := om.PopBack(); ok {
+		t.Fatalf("it should not be possible to pop anything from empty map")
+	}
+
+	for i, k := range keys {
+		om.Set(k, i)
+	}
+
+	for i := 0; i < len(keys); i++ {
+		if k, v, ok := om.PopFront(); !ok || k != keys[i] || v != i {
+			t.Fatalf("pop front, wanted: (%q, %d), got: (%q, %d)", keys[i], i, k, v)
+		}
+
+		if om.Len() != len(keys)-i-1 || om.Has(keys[i]) {
+			t.Fatalf("element with key %q was not removed as expected", keys[i])
+		}
+	}
+
+	if _, _, ok := om.PopFront(); ok {
+		t.Fatalf("it should not be possible to pop anything from drained map")
+	}
+
+	for i, k := range keys {
+		om.Set(k, i)
+	}
+
+	for i := len(keys) - 1; i >= 0; i-- {
+		if k, v, ok := om.PopBack(); !ok || k != keys[i] || v != i {
+			t.Fatalf("pop back, wanted: (%q, %d), got: (%q, %d)", keys[i], i, k, v)
+		}
+
+		if om.Len() != i || om.Has(keys[i]) {
+			t.Fatalf("element with key %q was not removed as expected", keys[i])
+		}
+	}
+
+	if _, _, ok := om.PopBack(); ok {
+		t.Fatalf("it should not be possible to pop anything from drained map")
+	}
+}
+
+func TestOrderedMapSetFront(t *testing.T) {
+	om := New[string, int]()
+
+	if val, ok := om.SetFront("a", 0); ok || val != 0 {
+		t.Fatalf("element with key %q should not exist", "a")
+	}
+
+	om.Set("b", 1)
+	om.SetFront("c", 2)
+
+	if val, ok := om.SetFront("b", 10); !ok || val != 1 {
+		t.Fatalf("set value, wanted: %d, got: %d", 1, val)
+	}
+
+	expected := []string{"c", "a", "b"}
+	if keys := om.Keys(); !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, keys)
+	}
+
+	if keys := reverseKeys(om); !reflect.DeepEqual(keys, reversed(expected)) {
+		t.Fatalf("wanted: %q, got: %q", reversed(expected), keys)
+	}
+
+	if k, _, _ := om.Front(); k != "c" {
+		t.Fatalf("front, wanted: %q, got: %q", "c", k)
+	}
+
+	if k, v, _ := om.Back(); k != "b" || v != 10 {
+		t.Fatalf("back, wanted: (%q, %d), got: (%q, %d)", "b", 10, k, v)
+	}
+}
+
+func TestOrderedMapSetReturnsOldValue(t *testing.T) {
+	om := New[string, int]()
+	om.Set("a", 0)
+
+	if val, ok := om.Set("a", 1); !ok || val != 0 {
+		t.Fatalf("set value, wanted: %d, got: %d", 0, val)
+	}
+
+	if val, _ := om.Get("a"); val != 1 {
+		t.Fatalf("get value, wanted: %d, got: %d", 1, val)
+	}
+}
+
+func TestOrderedMapGetOrSet(t *testing.T) {
+	om := New[string, int]()
+	om.Set("a", 0)
+	om.Set("b", 1)
+
+	if val, ok := om.GetOrSet("a", 10); !ok || val != 0 {
+		t.Fatalf("get or set value, wanted: %d, got: %d", 0, val)
+	}
+
+	if val, _ := om.Get("a"); val != 0 {
+		t.Fatalf("existing value should not be changed, wanted: %d, got: %d", 0, val)
+	}
+
+	if val, ok := om.GetOrSet("c", 2); ok || val != 2 {
+		t.Fatalf("get or set value, wanted: %d, got: %d", 2, val)
+	}
+
+	if val, ok := om.Get("c"); !ok || val != 2 {
+		t.Fatalf("get value, wanted: %d, got: %d", 2, val)
+	}
+
+	expected := []string{"a", "b", "c"}
+	if keys := om.Keys(); !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, keys)
+	}
+}
+
+func TestOrderedMapGetOrDefault(t *testing.T) {
+	om := New[string, int]()
+	om.Set("a", 1)
+
+	if val := om.GetOrDefault("a", 10); val != 1 {
+		t.Fatalf("get value, wanted: %d, got: %d", 1, val)
+	}
+
+	if val := om.GetOrDefault("missing", 10); val != 10 {
+		t.Fatalf("get value, wanted: %d, got: %d", 10, val)
+	}
+
+	if om.Len() != 1 || om.Has("missing") {
+		t.Fatalf("get with default should not modify a map")
+	}
+}
+
+func TestOrderedMapEqual(t *testing.T) {
+	eq := func(a, b int) bool { return a == b }
+
+	build := func(keys ...string) *OrderedMap[string, int] {
+		om := New[string, int]()
+		for _, k := range keys {
+			om.Set(k, len(k))
+		}
+		return om
+	}
+
+	tests := []struct {
+		a, b     *OrderedMap[string, int]
+		expected bool
+	}{
+		{build(), build(), true},
+		{build("a", "bb", "ccc"), build("a", "bb", "ccc"), true},
+		{build("a", "bb", "ccc"), build("a", "ccc", "bb"), false},
+		{build("a", "bb", "ccc"), build("a", "bb"), false},
+		{build("a", "bb"), build("a", "bb", "ccc"), false},
+		{build("a", "bb"), build("a", "cc"), false},
+	}
+
+	for i, test := range tests {
+		if actual := test.a.Equal(test.b, eq); actual != test.expected {
+			t.Fatalf("test %d, wanted: %t, got: %t", i, test.expected, actual)
+		}
+	}
+
+	a, b := build("a", "bb"), build("a", "bb")
+	b.Set("bb", 0)
+
+	if a.Equal(b, eq) {
+		t.Fatalf("maps with different values should not be equal")
+	}
+}
+
+func TestOrderedMapString(t *testing.T) {
+	om := New[string, int]()
+
+	if s := om.String(); s != "OrderedMap[]" {
+		t.Fatalf("wanted: %q, got: %q", "OrderedMap[]", s)
+	}
+
+	om.Set("d", 3)
+	om.Set("b", 8)
+	om.Set("c", 5)
+
+	const expected = "OrderedMap[d:3, b:8, c:5]"
+
+	if s := om.String(); s != expected {
+		t.Fatalf("wanted: %q, got: %q", expected, s)
+	}
+
+	if s := fmt.Sprintf("%v", om); s != expected {
+		t.Fatalf("wanted: %q, got: %q", expected, s)
+	}
+}
+
+func TestOrderedMapForEach(t *testing.T) {
+	expected := []string{"d", "b", "c", "a"}
+
+	om := New[string, int]()
+	for i, k := range expected {
+		om.Set(k, i)
+	}
+
+	var keys []string
+	om.ForEach(func(k string, v int) bool {
+		if v != len(keys) {
+			t.Fatalf("wanted: %d, got: %d", len(keys), v)
+		}
+		keys = append(keys, k)
+		return true
+	})
+
+	if !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, keys)
+	}
+
+	keys = keys[:0]
+	om.ForEach(func(k string, _ int) bool {
+		keys = append(keys, k)
+		return k != "b"
+	})
+
+	if !reflect.DeepEqual(keys, expected[:2]) {
+		t.Fatalf("wanted: %q, got: %q", expected[:2], keys)
+	}
+
+	if actual := om.Keys(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("iteration should not modify a map, wanted: %q, got: %q", expected, actual)
+	}
+}
+
+func TestOrderedMapRename(t *testing.T) {
+	om := New[string, int]()
+	om.Set("a", 0)
+	om.Set("b", 1)
+	om.Set("c", 2)
+
+	if !om.Rename("b", "bb") {
+		t.Fatalf("element with key %q should be renamed", "b")
+	}
+
+	expected := []string{"a", "bb", "c"}
+	if keys := om.Keys(); !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, keys)
+	}
+
+	if val, ok := om.Get("bb"); !ok || val != 1 {
+		t.Fatalf("get value, wanted: %d, got: %d", 1, val)
+	}
+
+	if om.Has("b") {
+		t.Fatalf("element with key %q should not exist after rename", "b")
+	}
+
+	if om.Rename("missing", "d") {
+		t.Fatalf("element with key %q doesn't exist, it cannot be renamed", "missing")
+	}
+
+	if om.Rename("a", "c") {
+		t.Fatalf("element with key %q already exists, it should not be overwritten", "c")
+	}
+
+	if values := om.Values(); !reflect.DeepEqual(values, []int{0, 1, 2}) {
+		t.Fatalf("wanted: %d, got: %d", []int{0, 1, 2}, values)
+	}
+
+	if keys := om.Keys(); !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, keys)
+	}
+}
+
+func TestOrderedMapAt(t *testing.T) {
+	om := New[string, int]()
+
+	if _, _, ok := om.At(0); ok {
+		t.Fatalf("it should not be possible to get anything from empty map")
+	}
+
+	keys := []string{"d", "b", "c", "a"}
+	for i, k := range keys {
+		om.Set(k, i)
+	}
+
+	for _, i := range []int{0, 2, len(keys) - 1} {
+		if k, v, ok := om.At(i); !ok || k != keys[i] || v != i {
+			t.Fatalf("at %d, wanted: (%q, %d), got: (%q, %d)", i, keys[i], i, k, v)
+		}
+	}
+
+	for _, i := range []int{-1, len(keys), len(keys) + 1} {
+		if k, v, ok := om.At(i); ok || k != "" || v != 0 {
+			t.Fatalf("index %d is out of range, got: (%q, %d)", i, k, v)
+		}
+	}
+}
+
+func TestOrderedMapIndexOf(t *testing.T) {
+	om := New[string, int]()
+
+	if i := om.IndexOf("missing"); i != -1 {
+		t.Fatalf("wanted: %d, got: %d", -1, i)
+	}
+
+	keys := []string{"d", "b", "c", "a"}
+	for i, k := range keys {
+		om.Set(k, i)
+	}
+
+	for i, k := range keys {
+		if actual := om.IndexOf(k); actual != i {
+			t.Fatalf("index of %q, wanted: %d, got: %d", k, i, actual)
+		}
+	}
+
+	if i := om.IndexOf("missing"); i != -1 {
+		t.Fatalf("wanted: %d, got: %d", -1, i)
+	}
+}
+
+func TestOrderedMapToMap(t *testing.T) {
+	om := New[string, int]()
+	om.Set("d", 3)
+	om.Set("b", 8)
+	om.Set("c", 5)
+
+	m := om.ToMap()
+
+	expected := map[string]int{"d": 3, "b": 8, "c": 5}
+	if !reflect.DeepEqual(m, expected) {
+		t.Fatalf("wanted: %v, got: %v", expected, m)
+	}
+
+	m["d"] = 0
+	m["a"] = 1
+
+	if val, _ := om.Get("d"); val != 3 || om.Has("a") || om.Len() != 3 {
+		t.Fatalf("modifying returned map should not affect ordered map")
+	}
+}
+
+func TestFromMapSorted(t *testing.T) {
+	m := map[string]int{"d": 3, "b": 8, "c": 5, "a": 9}
+
+	for i := 0; i < 10; i++ {
+		om := FromMapSorted(m)
+
+		expected := []string{"a", "b", "c", "d"}
+		if keys := om.Keys(); !reflect.DeepEqual(keys, expected) {
+			t.Fatalf("wanted: %q, got: %q", expected, keys)
+		}
+
+		if values := om.Values(); !reflect.DeepEqual(values, []int{9, 8, 5, 3}) {
+			t.Fatalf("wanted: %d, got: %d", []int{9, 8, 5, 3}, values)
+		}
+	}
+
+	if om := FromMapSorted(map[int]string{}); om.Len() != 0 {
+		t.Fatalf("wanted: %d, got: %d", 0, om.Len())
+	}
+}
+
+func TestOrderedMapSetMany(t *testing.T) {
+	om := New[string, int]()
+	om.Set("a", 0)
+	om.Set("b", 1)
+
+	om.SetMany(
+		Pair[string, int]{"d", 3},
+		Pair[string, int]{"b", 10},
+		Pair[string, int]{"c", 2},
+	)
+
+	om.SetMany()
+
+	expectedKeys := []string{"a", "b", "d", "c"}
+	if keys := om.Keys(); !reflect.DeepEqual(keys, expectedKeys) {
+		t.Fatalf("wanted: %q, got: %q", expectedKeys, keys)
+	}
+
+	expectedVals := []int{0, 10, 3, 2}
+	if values := om.Values(); !reflect.DeepEqual(values, expectedVals) {
+		t.Fatalf("wanted: %d, got: %d", expectedVals, values)
+	}
+}
+
+func TestOrderedMapDeleteMany(t *testing.T) {
+	om := New[string, int]()
+	om.Set("d", 3)
+	om.Set("b", 8)
+	om.Set("c", 5)
+	om.Set("a", 9)
+
+	if count := om.DeleteMany("b", "missing", "a", "b"); count != 2 {
+		t.Fatalf("wanted: %d, got: %d", 2, count)
+	}
+
+	if keys := om.Keys(); !reflect.DeepEqual(keys, []string{"d", "c"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"d", "c"}, keys)
+	}
+
+	if keys := reverseKeys(om); !reflect.DeepEqual(keys, []string{"c", "d"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"c", "d"}, keys)
+	}
+
+	if count := om.DeleteMany(); count != 0 {
+		t.Fatalf("wanted: %d, got: %d", 0, count)
+	}
+}
+
+func TestOrderedMapMerge(t *testing.T) {
+	om := New[string, int]()
+	om.Set("d", 3)
+	om.Set("b", 8)
+
+	other := New[string, int]()
+	other.Set("c", 5)
+	other.Set("b", 10)
+	other.Set("a", 9)
+
+	om.Merge(other)
+
+	expectedKeys := []string{"d", "b", "c", "a"}
+	if keys := om.Keys(); !reflect.DeepEqual(keys, expectedKeys) {
+		t.Fatalf("wanted: %q, got: %q", expectedKeys, keys)
+	}
+
+	expectedVals := []int{3, 10, 5, 9}
+	if values := om.Values(); !reflect.DeepEqual(values, expectedVals) {
+		t.Fatalf("wanted: %d, got: %d", expectedVals, values)
+	}
+
+	if keys := other.Keys(); !reflect.DeepEqual(keys, []string{"c", "b", "a"}) || other.Len() != 3 {
+		t.Fatalf("merge should not modify other map, got: %v", other)
+	}
+
+	empty := New[string, int]()
+	empty.Merge(other)
+
+	if keys := empty.Keys(); !reflect.DeepEqual(keys, []string{"c", "b", "a"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"c", "b", "a"}, keys)
+	}
+
+	if values := empty.Values(); !reflect.DeepEqual(values, []int{5, 10, 9}) {
+		t.Fatalf("wanted: %d, got: %d", []int{5, 10, 9}, values)
+	}
+}
+
+func TestOrderedMapSnapshotIterator(t *testing.T) {
+	om := New[string, int]()
+
+	if _, _, ok := om.SnapshotIterator()(); ok {
+		t.Fatalf("iterator over empty map should not yield anything")
+	}
+
+	for i, k := range []string{"a", "b", "c", "d", "e"} {
+		om.Set(k, i)
+	}
+
+	var keys []string
+	next := om.SnapshotIterator()
+	for k, v, ok := next(); ok; k, v, ok = next() {
+		keys = append(keys, k)
+
+		switch k {
+		case "a":
+			om.Delete("a")
+			om.Delete("c")
+			om.Set("b", 10)
+			om.Set("f", 5)
+		case "b":
+			if v != 10 {
+				t.Fatalf("get value, wanted: %d, got: %d", 10, v)
+			}
+			om.Delete("b")
+			om.Clear()
+			om.Set("e", 20)
+		case "e":
+			if v != 20 {
+				t.Fatalf("get value, wanted: %d, got: %d", 20, v)
+			}
+		}
+	}
+
+	expected := []string{"a", "b", "e"}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, keys)
+	}
+}
+
+func TestOrderedMapIteratorFrom(t *testing.T) {
+	keys := []string{"d", "b", "c", "a"}
+
+	om := New[string, int]()
+	for i, k := range keys {
+		om.Set(k, i)
+	}
+
+	for i, k := range keys {
+		next, ok := om.IteratorFrom(k)
+		if !ok {
+			t.Fatalf("element with key %q should exist", k)
+		}
+
+		var actual []string
+		for k, v, ok := next(); ok; k, v, ok = next() {
+			if v != i+len(actual) {
+				t.Fatalf("wanted: %d, got: %d", i+len(actual), v)
+			}
+			actual = append(actual, k)
+		}
+
+		if !reflect.DeepEqual(actual, keys[i:]) {
+			t.Fatalf("wanted: %q, got: %q", keys[i:], actual)
+		}
+	}
+
+	next, ok := om.IteratorFrom("missing")
+	if ok {
+		t.Fatalf("element with key %q should not exist", "missing")
+	}
+
+	if _, _, ok := next(); ok {
+		t.Fatalf("iterator from missing key should not yield anything")
+	}
+}
+
+func TestOrderedMapInsertAfter(t *testing.T) {
+	om := New[string, int]()
+	om.Set("a", 0)
+	om.Set("b", 1)
+	om.Set("c", 2)
+
+	tests := []struct {
+		anchor, key string
+		expected    []string
+	}{
+		{"a", "x", []string{"a", "x", "b", "c"}},
+		{"c", "y", []string{"a", "x", "b", "c", "y"}},
+		{"b", "z", []string{"a", "x", "b", "z", "c", "y"}},
+	}
+
+	for i, test := range tests {
+		if !om.InsertAfter(test.anchor, test.key, 10+i) {
+			t.Fatalf("element with key %q should be inserted after %q", test.key, test.anchor)
+		}
+
+		if keys := om.Keys(); !reflect.DeepEqual(keys, test.expected) {
+			t.Fatalf("wanted: %q, got: %q", test.expected, keys)
+		}
+
+		if keys := reverseKeys(om); !reflect.DeepEqual(keys, reversed(test.expected)) {
+			t.Fatalf("wanted: %q, got: %q", reversed(test.expected), keys)
+		}
+
+		if val, ok := om.Get(test.key); !ok || val != 10+i {
+			t.Fatalf("get value, wanted: %d, got: %d", 10+i, val)
+		}
+	}
+
+	if om.InsertAfter("missing", "w", 0) {
+		t.Fatalf("element with key %q doesn't exist, nothing can be inserted after it", "missing")
+	}
+
+	if om.InsertAfter("a", "b", 0) {
+		t.Fatalf("element with key %q already exists, it should not be inserted", "b")
+	}
+
+	if val, _ := om.Get("b"); val != 1 || om.Len() != 6 {
+		t.Fatalf("failed insert should not modify a map")
+	}
+}
+
+func TestOrderedMapInsertAfterWithCapacity(t *testing.T) {
+	om := NewWithCapacity[string, int](2)
+	om.Set("a", 0)
+	om.Set("b", 1)
+
+	if !om.InsertAfter("b", "c", 2) {
+		t.Fatalf("element with key %q should be inserted after %q", "c", "b")
+	}
+
+	if keys := om.Keys(); !reflect.DeepEqual(keys, []string{"b", "c"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"b", "c"}, keys)
+	}
+
+	if !om.InsertAfter("b", "d", 3) {
+		t.Fatalf("element with key %q should be inserted after %q", "d", "b")
+	}
+
+	if keys := om.Keys(); !reflect.DeepEqual(keys, []string{"d", "c"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"d", "c"}, keys)
+	}
+}
+
+func TestOrderedMapInsertBefore(t *testing.T) {
+	om := New[string, int]()
+	om.Set("a", 0)
+	om.Set("b", 1)
+	om.Set("c", 2)
+
+	tests := []struct {
+		anchor, key string
+		expected    []string
+	}{
+		{"a", "x", []string{"x", "a", "b", "c"}},
+		{"b", "y", []string{"x", "a", "y", "b", "c"}},
+		{"c", "z", []string{"x", "a", "y", "b", "z", "c"}},
+	}
+
+	for i, test := range tests {
+		if !om.InsertBefore(test.anchor, test.key, 10+i) {
+			t.Fatalf("element with key %q should be inserted before %q", test.key, test.anchor)
+		}
+
+		if keys := om.Keys(); !reflect.DeepEqual(keys, test.expected) {
+			t.Fatalf("wanted: %q, got: %q", test.expected, keys)
+		}
+
+		if keys := reverseKeys(om); !reflect.DeepEqual(keys, reversed(test.expected)) {
+			t.Fatalf("wanted: %q, got: %q", reversed(test.expected), keys)
+		}
+
+		if val, ok := om.Get(test.key); !ok || val != 10+i {
+			t.Fatalf("get value, wanted: %d, got: %d", 10+i, val)
+		}
+	}
+
+	if k, _, _ := om.Front(); k != "x" {
+		t.Fatalf("front, wanted: %q, got: %q", "x", k)
+	}
+
+	if k, _, _ := om.Back(); k != "c" {
+		t.Fatalf("back, wanted: %q, got: %q", "c", k)
+	}
+
+	if om.InsertBefore("missing", "w", 0) {
+		t.Fatalf("element with key %q doesn't exist, nothing can be inserted before it", "missing")
+	}
+
+	if om.InsertBefore("a", "b", 0) {
+		t.Fatalf("element with key %q already exists, it should not be inserted", "b")
+	}
+
+	if val, _ := om.Get("b"); val != 1 || om.Len() != 6 {
+		t.Fatalf("failed insert should not modify a map")
+	}
+}
+
+func TestOrderedMapMoveBeforeAndAfter(t *testing.T) {
+	om := New[string, int]()
+	om.Set("a", 0)
+	om.Set("b", 1)
+	om.Set("c", 2)
+	om.Set("d", 3)
+
+	tests := []struct {
+		move        func(string, string) bool
+		key, anchor string
+		expected    []string
+	}{
+		{om.MoveBefore, "c", "b", []string{"a", "c", "b", "d"}},
+		{om.MoveBefore, "a", "d", []string{"c", "b", "a", "d"}},
+		{om.MoveBefore, "d", "c", []string{"d", "c", "b", "a"}},
+		{om.MoveAfter, "d", "b", []string{"c", "b", "d", "a"}},
+		{om.MoveAfter, "b", "a", []string{"c", "d", "a", "b"}},
+		{om.MoveAfter, "a", "c", []string{"c", "a", "d", "b"}},
+		{om.MoveAfter, "c", "a", []string{"a", "c", "d", "b"}},
+	}
+
+	for _, test := range tests {
+		if !test.move(test.key, test.anchor) {
+			t.Fatalf("element with key %q should be moved relative to %q", test.key, test.anchor)
+		}
+
+		if keys := om.Keys(); !reflect.DeepEqual(keys, test.expected) {
+			t.Fatalf("wanted: %q, got: %q", test.expected, keys)
+		}
+
+		if keys := reverseKeys(om); !reflect.DeepEqual(keys, reversed(test.expected)) {
+			t.Fatalf("wanted: %q, got: %q", reversed(test.expected), keys)
+		}
+	}
+
+	if values := om.Values(); !reflect.DeepEqual(values, []int{0, 2, 3, 1}) {
+		t.Fatalf("wanted: %d, got: %d", []int{0, 2, 3, 1}, values)
+	}
+
+	for _, move := range []func(string, string) bool{om.MoveBefore, om.MoveAfter} {
+		if move("a", "a") || move("missing", "a") || move("a", "missing") {
+			t.Fatalf("element should not be moved relative to itself or a missing key")
+		}
+	}
+}
+
+func TestOrderedMapSwap(t *testing.T) {
+	om := New[string, int]()
+	om.Set("a", 0)
+	om.Set("b", 1)
+	om.Set("c", 2)
+	om.Set("d", 3)
+
+	tests := []struct {
+		a, b     string
+		expected []string
+	}{
+		{"a", "b", []string{"b", "a", "c", "d"}},
+		{"a", "d", []string{"b", "d", "c", "a"}},
+		{"a", "b", []string{"a", "d", "c", "b"}},
+		{"c", "c", []string{"a", "d", "c", "b"}},
+	}
+
+	for _, test := range tests {
+		if !om.Swap(test.a, test.b) {
+			t.Fatalf("elements with keys %q and %q should be swapped", test.a, test.b)
+		}
+
+		if keys := om.Keys(); !reflect.DeepEqual(keys, test.expected) {
+			t.Fatalf("wanted: %q, got: %q", test.expected, keys)
+		}
+
+		if keys := reverseKeys(om); !reflect.DeepEqual(keys, reversed(test.expected)) {
+			t.Fatalf("wanted: %q, got: %q", reversed(test.expected), keys)
+		}
+	}
+
+	for i, k := range []string{"a", "b", "c", "d"} {
+		if val, _ := om.Get(k); val != i {
+			t.Fatalf("get value, wanted: %d, got: %d", i, val)
+		}
+	}
+
+	om.Delete("d")
+	if keys := om.Keys(); !reflect.DeepEqual(keys, []string{"a", "c", "b"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"a", "c", "b"}, keys)
+	}
+
+	if om.Swap("a", "missing") || om.Swap("missing", "a") {
+		t.Fatalf("element with key %q doesn't exist, it cannot be swapped", "missing")
+	}
+}
+
+func TestOrderedMapCap(t *testing.T) {
+	if c := New[string, int]().Cap(); c != 0 {
+		t.Fatalf("wanted: %d, got: %d", 0, c)
+	}
+
+	om := NewWithCapacity[string, int](5)
+	om.Set("a", 0)
+
+	if c := om.Cap(); c != 5 {
+		t.Fatalf("wanted: %d, got: %d", 5, c)
+	}
+
+	if c := om.Clone().Cap(); c != 5 {
+		t.Fatalf("wanted: %d, got: %d", 5, c)
+	}
+
+	om.Clear()
+
+	if c := om.Cap(); c != 5 {
+		t.Fatalf("wanted: %d, got: %d", 5, c)
+	}
+}
+
+func TestOrderedMapReserve(t *testing.T) {
+	om := New[int, int]()
+	om.Reserve(0)
+	om.Set(1, 1)
+	om.Set(0, 0)
+	om.Reserve(1000)
+
+	for i := 2; i < 1000; i++ {
+		om.Set(i, i)
+	}
+
+	if om.Len() != 1000 {
+		t.Fatalf("wanted: %d, got: %d", 1000, om.Len())
+	}
+
+	keys := om.Keys()
+	if keys[0] != 1 || keys[1] != 0 || keys[999] != 999 {
+		t.Fatalf("reserve should not change keys order, got: %d", keys[:3])
+	}
+
+	for k, v := range om.All() {
+		if k != v {
+			t.Fatalf("get value, wanted: %d, got: %d", k, v)
+		}
+	}
+}
+
+func BenchmarkOrderedMapSet(b *testing.B) {
+	const count = 10000
+
+	b.Run("Default", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			om := New[int, int]()
+			for j := 0; j < count; j++ {
+				om.Set(j, j)
+			}
+		}
+	})
+
+	b.Run("Reserved", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			om := New[int, int]()
+			om.Reserve(count)
+			for j := 0; j < count; j++ {
+				om.Set(j, j)
+			}
+		}
+	})
+}
+
+func TestOrderedMapPairs(t *testing.T) {
+	om := New[string, int]()
+
+	if pairs := om.Pairs(); pairs == nil || len(pairs) != 0 {
+		t.Fatalf("wanted: empty non-nil slice, got: %#v", pairs)
+	}
+
+	om.Set("d", 3)
+	om.Set("b", 8)
+	om.Set("c", 5)
+
+	expected := []Pair[string, int]{{"d", 3}, {"b", 8}, {"c", 5}}
+
+	pairs := om.Pairs()
+	if !reflect.DeepEqual(pairs, expected) {
+		t.Fatalf("wanted: %v, got: %v", expected, pairs)
+	}
+
+	pairs[0].Key = "modified"
+	pairs[1].Value = 0
+
+	if actual := om.Pairs(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("modifying returned slice should not affect a map, wanted: %v, got: %v", expected, actual)
+	}
+}
+
+func TestOrderedMapUpdate(t *testing.T) {
+	incr := func(old int, _ bool) int { return old + 1 }
+
+	om := New[string, int]()
+	om.Set("a", 5)
+	om.Set("b", 1)
+
+	if val := om.Update("a", incr); val != 6 {
+		t.Fatalf("update value, wanted: %d, got: %d", 6, val)
+	}
+
+	if val := om.Update("c", func(old int, exists bool) int {
+		if exists || old != 0 {
+			t.Fatalf("element with key %q should not exist", "c")
+		}
+		return 10
+	}); val != 10 {
+		t.Fatalf("update value, wanted: %d, got: %d", 10, val)
+	}
+
+	om.Update("c", incr)
+
+	if keys := om.Keys(); !reflect.DeepEqual(keys, []string{"a", "b", "c"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"a", "b", "c"}, keys)
+	}
+
+	if values := om.Values(); !reflect.DeepEqual(values, []int{6, 1, 11}) {
+		t.Fatalf("wanted: %d, got: %d", []int{6, 1, 11}, values)
+	}
+}
+
+func TestOrderedMapPop(t *testing.T) {
+	om := New[string, int]()
+	om.Set("a", 0)
+	om.Set("b", 1)
+	om.Set("c", 2)
+
+	if val, ok := om.Pop("b"); !ok || val != 1 {
+		t.Fatalf("pop value, wanted: %d, got: %d", 1, val)
+	}
+
+	if om.Len() != 2 || om.Has("b") {
+		t.Fatalf("element with key %q was not removed as expected", "b")
+	}
+
+	if val, ok := om.Pop("missing"); ok || val != 0 {
+		t.Fatalf("element with key %q doesn't exist, it cannot be popped", "missing")
+	}
+
+	if om.Len() != 2 {
+		t.Fatalf("wanted: %d, got: %d", 2, om.Len())
+	}
+}
+
+func TestOrderedMapSetIfAbsent(t *testing.T) {
+	om := New[string, int]()
+	om.Set("a", 0)
+	om.Set("b", 1)
+
+	if !om.SetIfAbsent("c", 2) {
+		t.Fatalf("element with key %q should be added", "c")
+	}
+
+	if val, ok := om.Get("c"); !ok || val != 2 {
+		t.Fatalf("get value, wanted: %d, got: %d", 2, val)
+	}
+
+	if om.SetIfAbsent("a", 10) {
+		t.Fatalf("element with key %q already exists, it should not be overwritten", "a")
+	}
+
+	if val, _ := om.Get("a"); val != 0 {
+		t.Fatalf("get value, wanted: %d, got: %d", 0, val)
+	}
+
+	if keys := om.Keys(); !reflect.DeepEqual(keys, []string{"a", "b", "c"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"a", "b", "c"}, keys)
+	}
+}
+
+func TestOrderedMapReplace(t *testing.T) {
+	om := New[string, int]()
+	om.Set("a", 0)
+	om.Set("b", 1)
+
+	if val, ok := om.Replace("a", 10); !ok || val != 0 {
+		t.Fatalf("replace value, wanted: %d, got: %d", 0, val)
+	}
+
+	if val, _ := om.Get("a"); val != 10 {
+		t.Fatalf("get value, wanted: %d, got: %d", 10, val)
+	}
+
+	if val, ok := om.Replace("missing", 2); ok || val != 0 {
+		t.Fatalf("element with key %q doesn't exist, it cannot be replaced", "missing")
+	}
+
+	if om.Has("missing") {
+		t.Fatalf("element with key %q should not be added", "missing")
+	}
+
+	if keys := om.Keys(); !reflect.DeepEqual(keys, []string{"a", "b"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"a", "b"}, keys)
+	}
+}
+
+func TestOrderedMapCompact(t *testing.T) {
+	om := New[int, int]()
+	for i := 0; i < 1000; i++ {
+		om.Set(i, i)
+	}
+
+	for i := 0; i < 1000; i++ {
+		if i%100 != 0 {
+			om.Delete(i)
+		}
+	}
+
+	om.Compact()
+
+	expected := []int{0, 100, 200, 300, 400, 500, 600, 700, 800, 900}
+	if keys := om.Keys(); !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("wanted: %d, got: %d", expected, keys)
+	}
+
+	if values := om.Values(); !reflect.DeepEqual(values, expected) {
+		t.Fatalf("wanted: %d, got: %d", expected, values)
+	}
+
+	om.Delete(500)
+	om.Set(1, 1)
+
+	if keys := om.Keys(); !reflect.DeepEqual(keys, []int{0, 100, 200, 300, 400, 600, 700, 800, 900, 1}) {
+		t.Fatalf("wanted: %d, got: %d", []int{0, 100, 200, 300, 400, 600, 700, 800, 900, 1}, keys)
+	}
+}
+
+func TestOrderedMapInsertAt(t *testing.T) {
+	om := New[string, int]()
+
+	tests := []struct {
+		index    int
+		key      string
+		expected []string
+	}{
+		{0, "a", []string{"a"}},
+		{0, "b", []string{"b", "a"}},
+		{2, "c", []string{"b", "a", "c"}},
+		{1, "d", []string{"b", "d", "a", "c"}},
+		{3, "e", []string{"b", "d", "a", "e", "c"}},
+	}
+
+	for i, test := range tests {
+		if !om.InsertAt(test.index, test.key, i) {
+			t.Fatalf("element with key %q should be inserted at %d", test.key, test.index)
+		}
+
+		if keys := om.Keys(); !reflect.DeepEqual(keys, test.expected) {
+			t.Fatalf("wanted: %q, got: %q", test.expected, keys)
+		}
+
+		if keys := reverseKeys(om); !reflect.DeepEqual(keys, reversed(test.expected)) {
+			t.Fatalf("wanted: %q, got: %q", reversed(test.expected), keys)
+		}
+
+		if val, _ := om.Get(test.key); val != i {
+			t.Fatalf("get value, wanted: %d, got: %d", i, val)
+		}
+	}
+
+	for _, index := range []int{-1, om.Len() + 1} {
+		if om.InsertAt(index, "x", 0) {
+			t.Fatalf("index %d is out of range, nothing should be inserted", index)
+		}
+	}
+
+	if om.InsertAt(0, "a", 0) {
+		t.Fatalf("element with key %q already exists, it should not be inserted", "a")
+	}
+
+	if om.Len() != 5 {
+		t.Fatalf("wanted: %d, got: %d", 5, om.Len())
+	}
+
+	bounded := []struct {
+		index    int
+		expected []string
+	}{
+		{0, []string{"x", "b", "c"}},
+		{1, []string{"b", "x", "c"}},
+		{2, []string{"b", "c", "x"}},
+		{3, []string{"b", "c", "x"}},
+	}
+
+	for _, test := range bounded {
+		om := NewWithCapacity[string, int](3)
+		om.Set("a", 1)
+		om.Set("b", 2)
+		om.Set("c", 3)
+
+		if !om.InsertAt(test.index, "x", 9) {
+			t.Fatalf("element with key %q should be inserted at %d", "x", test.index)
+		}
+
+		if keys := om.Keys(); !reflect.DeepEqual(keys, test.expected) {
+			t.Fatalf("insert at %d, wanted: %q, got: %q", test.index, test.expected, keys)
+		}
+
+		if keys := reverseKeys(om); !reflect.DeepEqual(keys, reversed(test.expected)) {
+			t.Fatalf("insert at %d, wanted: %q, got: %q", test.index, reversed(test.expected), keys)
+		}
+
+		if err := om.validate(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestOrderedMapRemoveAt(t *testing.T) {
+	om := New[string, int]()
+	for i, k := range []string{"a", "b", "c", "d", "e"} {
+		om.Set(k, i)
+	}
+
+	tests := []struct {
+		index    int
+		key      string
+		val      int
+		expected []string
+	}{
+		{0, "a", 0, []string{"b", "c", "d", "e"}},
+		{3, "e", 4, []string{"b", "c", "d"}},
+		{1, "c", 2, []string{"b", "d"}},
+	}
+
+	for _, test := range tests {
+		if k, v, ok := om.RemoveAt(test.index); !ok || k != test.key || v != test.val {
+			t.Fatalf("remove at %d, wanted: (%q, %d), got: (%q, %d)", test.index, test.key, test.val, k, v)
+		}
+
+		if keys := om.Keys(); !reflect.DeepEqual(keys, test.expected) {
+			t.Fatalf("wanted: %q, got: %q", test.expected, keys)
+		}
+
+		if keys := reverseKeys(om); !reflect.DeepEqual(keys, reversed(test.expected)) {
+			t.Fatalf("wanted: %q, got: %q", reversed(test.expected), keys)
+		}
+
+		if om.Has(test.key) {
+			t.Fatalf("element with key %q was not removed as expected", test.key)
+		}
+	}
+
+	for _, index := range []int{-1, om.Len()} {
+		if k, v, ok := om.RemoveAt(index); ok || k != "" || v != 0 {
+			t.Fatalf("index %d is out of range, nothing should be removed", index)
+		}
+	}
+
+	if om.Len() != 2 {
+		t.Fatalf("wanted: %d, got: %d", 2, om.Len())
+	}
+}
+
+func TestOrderedMapDiff(t *testing.T) {
+	eq := func(a, b int) bool { return a == b }
+
+	om := New[string, int]()
+	om.Set("d", 3)
+	om.Set("b", 8)
+	om.Set("c", 5)
+	om.Set("a", 9)
+
+	other := New[string, int]()
+	other.Set("f", 1)
+	other.Set("a", 9)
+	other.Set("c", 6)
+	other.Set("e", 2)
+	other.Set("d", 0)
+
+	added, removed, changed := om.Diff(other, eq)
+
+	if !reflect.DeepEqual(added, []string{"f", "e"}) {
+		t.Fatalf("added, wanted: %q, got: %q", []string{"f", "e"}, added)
+	}
+
+	if !reflect.DeepEqual(removed, []string{"b"}) {
+		t.Fatalf("removed, wanted: %q, got: %q", []string{"b"}, removed)
+	}
+
+	if !reflect.DeepEqual(changed, []string{"d", "c"}) {
+		t.Fatalf("changed, wanted: %q, got: %q", []string{"d", "c"}, changed)
+	}
+
+	added, removed, changed = om.Diff(om.Clone(), eq)
+
+	if len(added) != 0 || len(removed) != 0 || len(changed) != 0 {
+		t.Fatalf("identical maps should not differ, got: %q, %q, %q", added, removed, changed)
+	}
+}
+
+func TestOrderedMapSameOrder(t *testing.T) {
+	build := func(keys ...string) *OrderedMap[string, int] {
+		om := New[string, int]()
+		for i, k := range keys {
+			om.Set(k, i)
+		}
+		return om
+	}
+
+	om := build("a", "b", "c")
+
+	other := build("c", "b", "a")
+	other.Reverse()
+
+	tests := []struct {
+		other    *OrderedMap[string, int]
+		expected bool
+	}{
+		{build("a", "b", "c"), true},
+		{other, true},
+		{build("b", "a", "c"), false},
+		{build("a", "b", "d"), false},
+		{build("a", "b"), false},
+	}
+
+	for i, test := range tests {
+		if actual := om.SameOrder(test.other); actual != test.expected {
+			t.Fatalf("test %d, wanted: %t, got: %t", i, test.expected, actual)
+		}
+	}
+}
+
+func TestOrderedMapPeek(t *testing.T) {
+	om := NewWithCapacity[string, int](3)
+	om.Set("a", 0)
+	om.Set("b", 1)
+	om.Set("c", 2)
+
+	if val, ok := om.Peek("a"); !ok || val != 0 {
+		t.Fatalf("peek value, wanted: %d, got: %d", 0, val)
+	}
+
+	if keys := om.Keys(); !reflect.DeepEqual(keys, []string{"a", "b", "c"}) {
+		t.Fatalf("peek should not change keys order, got: %q", keys)
+	}
+
+	if val, ok := om.Peek("missing"); ok || val != 0 {
+		t.Fatalf("element with key %q should not exist", "missing")
+	}
+
+	om.GetLRU("a")
+
+	if keys := om.Keys(); !reflect.DeepEqual(keys, []string{"b", "c", "a"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"b", "c", "a"}, keys)
+	}
+
+	om.Peek("b")
+	om.Set("d", 3)
+
+	if keys := om.Keys(); !reflect.DeepEqual(keys, []string{"c", "a", "d"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"c", "a", "d"}, keys)
+	}
+}
+
+func TestOrderedMapDeleteFunc(t *testing.T) {
+	build := func() *OrderedMap[string, int] {
+		om := New[string, int]()
+		for i, k := range []string{"a", "b", "c", "d", "e"} {
+			om.Set(k, i)
+		}
+		return om
+	}
+
+	tests := []struct {
+		pred     func(string, int) bool
+		count    int
+		expected []string
+	}{
+		{func(_ string, v int) bool { return v%2 == 1 }, 2, []string{"a", "c", "e"}},
+		{func(string, int) bool { return true }, 5, []string{}},
+		{func(string, int) bool { return false }, 0, []string{"a", "b", "c", "d", "e"}},
+	}
+
+	for _, test := range tests {
+		om := build()
+
+		if count := om.DeleteFunc(test.pred); count != test.count {
+			t.Fatalf("wanted: %d, got: %d", test.count, count)
+		}
+
+		if keys := om.Keys(); !reflect.DeepEqual(keys, test.expected) {
+			t.Fatalf("wanted: %q, got: %q", test.expected, keys)
+		}
+
+		if keys := reverseKeys(om); !reflect.DeepEqual(keys, reversed(test.expected)) {
+			t.Fatalf("wanted: %q, got: %q", reversed(test.expected), keys)
+		}
+
+		if om.Len() != len(test.expected) {
+			t.Fatalf("wanted: %d, got: %d", len(test.expected), om.Len())
+		}
+	}
+}
+
+func TestOrderedMapIteratorIndexed(t *testing.T) {
+	om := New[string, int]()
+
+	if _, _, _, ok := om.IteratorIndexed()(); ok {
+		t.Fatalf("iterator over empty map should not yield anything")
+	}
+
+	keys := []string{"d", "b", "c", "a"}
+	for i, k := range keys {
+		om.Set(k, i*10)
+	}
+
+	count := 0
+	next := om.IteratorIndexed()
+	for i, k, v, ok := next(); ok; i, k, v, ok = next() {
+		if i != count || k != keys[i] || v != i*10 {
+			t.Fatalf("wanted: (%d, %q, %d), got: (%d, %q, %d)", count, keys[count], count*10, i, k, v)
+		}
+		count++
+	}
+
+	if count != len(keys) {
+		t.Fatalf("wanted: %d, got: %d", len(keys), count)
+	}
+
+	if i, k, v, ok := next(); ok || i != 0 || k != "" || v != 0 {
+		t.Fatalf("exhausted iterator should not yield anything")
+	}
+}
+
+func TestOrderedMapApply(t *testing.T) {
+	om := New[string, string]()
+	om.Set("d", "delta")
+	om.Set("b", "bravo")
+	om.Set("c", "charlie")
+
+	var visited []string
+	om.Apply(func(k, v string) string {
+		visited = append(visited, k)
+		return strings.ToUpper(v)
+	})
+
+	expected := []string{"d", "b", "c"}
+	if !reflect.DeepEqual(visited, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, visited)
+	}
+
+	if keys := om.Keys(); !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, keys)
+	}
+
+	if values := om.Values(); !reflect.DeepEqual(values, []string{"DELTA", "BRAVO", "CHARLIE"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"DELTA", "BRAVO", "CHARLIE"}, values)
+	}
+}
+
+func TestOrderedMapValidate(t *testing.T) {
+	om := New[string, int]()
+
+	if err := om.validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	steps := []func(){
+		func() { om.Set("a", 0); om.Set("b", 1); om.Set("c", 2) },
+		func() { om.SetFront("d", 3) },
+		func() { om.InsertAfter("a", "e", 4) },
+		func() { om.InsertBefore("d", "f", 5) },
+		func() { om.InsertAt(3, "g", 6) },
+		func() { om.MoveToFront("c") },
+		func() { om.MoveToBack("f") },
+		func() { om.MoveBefore("f", "c") },
+		func() { om.MoveAfter("c", "g") },
+		func() { om.Swap("a", "f") },
+		func() { om.Rename("b", "h") },
+		func() { om.GetLRU("d") },
+		func() { om.Reverse() },
+		func() { om.Rotate(3) },
+		func() { om.SortByKey(func(a, b string) bool { return a < b }) },
+		func() { om.SortByValue(func(a, b int) bool { return a > b }) },
+		func() { om.RemoveAt(2) },
+		func() { om.PopFront() },
+		func() { om.PopBack() },
+		func() { om.DeleteFunc(func(k string, _ int) bool { return k == "e" }) },
+		func() { om.Compact() },
+		func() { om.Delete("c") },
+		func() { om.Clear() },
+	}
+
+	for i, step := range steps {
+		step()
+
+		if err := om.validate(); err != nil {
+			t.Fatalf("step %d, unexpected error: %v", i, err)
+		}
+	}
+}
+
+func TestOrderedMapValidateCorrupted(t *testing.T) {
+	build := func() *OrderedMap[string, int] {
+		om := New[string, int]()
+		for i, k := range []string{"a", "b", "c", "d"} {
+			om.Set(k, i)
+		}
+		return om
+	}
+
+	corruptions := []func(om *OrderedMap[string, int]){
+		func(om *OrderedMap[string, int]) { om.items.tail = nil },
+		func(om *OrderedMap[string, int]) { om.items.head.prev = om.items.tail },
+		func(om *OrderedMap[string, int]) { om.items.tail.next = om.items.head },
+		func(om *OrderedMap[string, int]) { om.items.head.next.prev = nil },
+		func(om *OrderedMap[string, int]) { om.items.tail = om.items.tail.prev },
+		func(om *OrderedMap[string, int]) { om.items.head.next.value = "x" },
+		func(om *OrderedMap[string, int]) { om.data["a"].item = om.items.tail },
+		func(om *OrderedMap[string, int]) { delete(om.data, "b") },
+		func(om *OrderedMap[string, int]) { om.items.remove(om.data["b"].item) },
+		func(om *OrderedMap[string, int]) {
+			b, c := om.data["b"].item, om.data["c"].item
+			c.next = b
+		},
+	}
+
+	for i, corrupt := range corruptions {
+		om := build()
+		corrupt(om)
+
+		if err := om.validate(); err == nil {
+			t.Fatalf("corruption %d should be detected", i)
+		}
+	}
+}
+
+func TestZip(t *testing.T) {
+	om, err := Zip([]string{"d", "b", "c"}, []int{3, 8, 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if s := om.String(); s != "OrderedMap[d:3, b:8, c:5]" {
+		t.Fatalf("wanted: %s, got: %s", "OrderedMap[d:3, b:8, c:5]", s)
+	}
+
+	om, err = Zip([]string{"d", "b", "d"}, []int{3, 8, 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if s := om.String(); s != "OrderedMap[d:5, b:8]" {
+		t.Fatalf("wanted: %s, got: %s", "OrderedMap[d:5, b:8]", s)
+	}
+
+	om, err = Zip[string, int](nil, nil)
+	if err != nil || om.Len() != 0 {
+		t.Fatalf("zipping empty slices should produce empty map, got: %v, %v", om, err)
+	}
+
+	if om, err := Zip([]string{"d", "b"}, []int{3}); err == nil || om != nil {
+		t.Fatalf("zipping slices of different length should fail")
+	}
+}
+
+func TestOrderedMapInterleaveIterator(t *testing.T) {
+	tests := []struct {
+		keys, expected []string
+	}{
+		{[]string{}, nil},
+		{[]string{"a"}, []string{"a"}},
+		{[]string{"a", "b"}, []string{"a", "b"}},
+		{[]string{"a", "b", "c", "d"}, []string{"a", "d", "b", "c"}},
+		{[]string{"a", "b", "c", "d", "e"}, []string{"a", "e", "b", "d", "c"}},
+	}
+
+	for _, test := range tests {
+		om := New[string, int]()
+		for i, k := range test.keys {
+			om.Set(k, i)
+		}
+
+		var keys []string
+		next := om.InterleaveIterator()
+		for k, v, ok := next(); ok; k, v, ok = next() {
+			if test.keys[v] != k {
+				t.Fatalf("get value, wanted: %q, got: %q", test.keys[v], k)
+			}
+			keys = append(keys, k)
+		}
+
+		if !reflect.DeepEqual(keys, test.expected) {
+			t.Fatalf("wanted: %q, got: %q", test.expected, keys)
+		}
+
+		if _, _, ok := next(); ok {
+			t.Fatalf("exhausted iterator should not yield anything")
+		}
+	}
+}
+
+func TestOrderedMapNextAndPrev(t *testing.T) {
+	om := New[string, int]()
+	om.Set("a", 0)
+	om.Set("b", 1)
+	om.Set("c", 2)
+
+	if k, v, ok := om.Next("a"); !ok || k != "b" || v != 1 {
+		t.Fatalf("next, wanted: (%q, %d), got: (%q, %d)", "b", 1, k, v)
+	}
+
+	if k, v, ok := om.Next("b"); !ok || k != "c" || v != 2 {
+		t.Fatalf("next, wanted: (%q, %d), got: (%q, %d)", "c", 2, k, v)
+	}
+
+	if k, v, ok := om.Prev("b"); !ok || k != "a" || v != 0 {
+		t.Fatalf("prev, wanted: (%q, %d), got: (%q, %d)", "a", 0, k, v)
+	}
+
+	if k, v, ok := om.Prev("c"); !ok || k != "b" || v != 1 {
+		t.Fatalf("prev, wanted: (%q, %d), got: (%q, %d)", "b", 1, k, v)
+	}
+
+	if k, v, ok := om.Next("c"); ok || k != "" || v != 0 {
+		t.Fatalf("last element should not have next one, got: (%q, %d)", k, v)
+	}
+
+	if k, v, ok := om.Prev("a"); ok || k != "" || v != 0 {
+		t.Fatalf("first element should not have previous one, got: (%q, %d)", k, v)
+	}
+
+	if _, _, ok := om.Next("missing"); ok {
+		t.Fatalf("element with key %q doesn't exist, it cannot have next one", "missing")
+	}
+
+	if _, _, ok := om.Prev("missing"); ok {
+		t.Fatalf("element with key %q doesn't exist, it cannot have previous one", "missing")
+	}
+}
+
+func TestOrderedMapRetain(t *testing.T) {
+	build := func() *OrderedMap[string, int] {
+		om := New[string, int]()
+		for i, k := range []string{"a", "b", "c", "d", "e"} {
+			om.Set(k, i)
+		}
+		return om
+	}
+
+	tests := []struct {
+		pred     func(string, int) bool
+		count    int
+		expected []string
+	}{
+		{func(_ string, v int) bool { return v%2 == 1 }, 3, []string{"b", "d"}},
+		{func(string, int) bool { return true }, 0, []string{"a", "b", "c", "d", "e"}},
+		{func(string, int) bool { return false }, 5, []string{}},
+	}
+
+	for _, test := range tests {
+		om := build()
+
+		if count := om.Retain(test.pred); count != test.count {
+			t.Fatalf("wanted: %d, got: %d", test.count, count)
+		}
+
+		if keys := om.Keys(); !reflect.DeepEqual(keys, test.expected) {
+			t.Fatalf("wanted: %q, got: %q", test.expected, keys)
+		}
+
+		if err := om.validate(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestOrderedMapDedupeValues(t *testing.T) {
+	om := New[string, int]()
+	om.Set("a", 1)
+	om.Set("b", 2)
+	om.Set("c", 1)
+	om.Set("d", 3)
+	om.Set("e", 2)
+	om.Set("f", 1)
+
+	eq := func(a, b int) bool { return a == b }
+
+	if count := om.DedupeValues(eq); count != 3 {
+		t.Fatalf("wanted: %d, got: %d", 3, count)
+	}
+
+	if keys := om.Keys(); !reflect.DeepEqual(keys, []string{"a", "b", "d"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"a", "b", "d"}, keys)
+	}
+
+	if values := om.Values(); !reflect.DeepEqual(values, []int{1, 2, 3}) {
+		t.Fatalf("wanted: %v, got: %v", []int{1, 2, 3}, values)
+	}
+
+	if count := om.DedupeValues(eq); count != 0 {
+		t.Fatalf("wanted: %d, got: %d", 0, count)
+	}
+
+	if err := om.validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestOrderedMapWith(t *testing.T) {
+	om := New[string, int]().With("d", 3).With("b", 8).With("c", 5).With("d", 4)
+
+	if keys := om.Keys(); !reflect.DeepEqual(keys, []string{"d", "b", "c"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"d", "b", "c"}, keys)
+	}
+
+	if values := om.Values(); !reflect.DeepEqual(values, []int{4, 8, 5}) {
+		t.Fatalf("wanted: %v, got: %v", []int{4, 8, 5}, values)
+	}
+
+	if res := om.With("e", 1); res != om {
+		t.Fatalf("With should return the same map")
+	}
+}
+
+func TestOrderedMapHash(t *testing.T) {
+	hashKey := func(k string) uint64 {
+		h := fnv.New64a()
+		h.Write([]byte(k))
+		return h.Sum64()
+	}
+	hashVal := func(v int) uint64 { return uint64(v) }
+
+	a := New[string, int]().With("a", 1).With("b", 2).With("c", 3)
+	b := New[string, int]().With("a", 1).With("b", 2).With("c", 3)
+	c := New[string, int]().With("b", 2).With("a", 1).With("c", 3)
+	d := New[string, int]().With("a", 1).With("b", 2).With("c", 4)
+
+	if ha, hb := a.Hash(hashKey, hashVal), b.Hash(hashKey, hashVal); ha != hb {
+		t.Fatalf("equal maps should have equal hashes, got: %d and %d", ha, hb)
+	}
+
+	if ha, hc := a.Hash(hashKey, hashVal), c.Hash(hashKey, hashVal); ha == hc {
+		t.Fatalf("maps with different order should have different hashes, got: %d", ha)
+	}
+
+	if ha, hd := a.Hash(hashKey, hashVal), d.Hash(hashKey, hashVal); ha == hd {
+		t.Fatalf("maps with different values should have different hashes, got: %d", ha)
+	}
+
+	// expected values are computed with standard FNV-1a, so they must not change across runs.
+	if h := New[string, int]().Hash(hashKey, hashVal); h != 14695981039346656037 {
+		t.Fatalf("wanted: %d, got: %d", uint64(14695981039346656037), h)
+	}
+
+	ident := func(v int) uint64 { return uint64(v) }
+	if h := New[int, int]().With(1, 2).Hash(ident, ident); h != 0x7717980363c8e066 {
+		t.Fatalf("wanted: %#x, got: %#x", 0x7717980363c8e066, h)
+	}
+}
+
+func TestOrderedMapKeepLast(t *testing.T) {
+	keys := []string{"a", "b", "c", "d", "e"}
+
+	tests := []struct {
+		n        int
+		count    int
+		expected []string
+	}{
+		{3, 2, []string{"c", "d", "e"}},
+		{5, 0, keys},
+		{10, 0, keys},
+		{0, 5, []string{}},
+		{-1, 5, []string{}},
+	}
+
+	for _, test := range tests {
+		om := New[string, int]()
+		for i, k := range keys {
+			om.Set(k, i)
+		}
+
+		if count := om.KeepLast(test.n); count != test.count {
+			t.Fatalf("keep last %d, wanted: %d, got: %d", test.n, test.count, count)
+		}
+
+		if k := om.Keys(); !reflect.DeepEqual(k, test.expected) {
+			t.Fatalf("keep last %d, wanted: %q, got: %q", test.n, test.expected, k)
+		}
+
+		if err := om.validate(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
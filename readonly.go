@@ -0,0 +1,25 @@
+package orderedmap
+
+// ReadOnlyMap is a read-only view of an ordered map.
+//
+// It allows to pass a map to code which should not modify it.
+type ReadOnlyMap[K comparable, V any] interface {
+	// Get returns a value associated with `key` and reports whether `key` is present in a map.
+	Get(key K) (V, bool)
+	// Len returns the number of entries in a map.
+	Len() int
+	// Iterator returns a function which iterates over key->value pairs of a map in keys insertion order.
+	Iterator() func() (K, V, bool)
+	// Has reports whether `key` is present in a map.
+	Has(key K) bool
+}
+
+var _ ReadOnlyMap[string, int] = (*OrderedMap[string, int])(nil)
+
+// AsReadOnly returns a read-only view of a map.
+//
+// NOTE: the view is backed by the same map, so modifications made through `om` are visible through the view.
+// The view restricts the set of available methods, but does not prevent a type assertion back to *OrderedMap.
+func (om *OrderedMap[K, V]) AsReadOnly() ReadOnlyMap[K, V] {
+	return om
+}
@@ -0,0 +1,50 @@
+package orderedmap
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestOrderedMapAsReadOnly(t *testing.T) {
+	om := New[string, int]()
+	om.Set("d", 3)
+	om.Set("b", 8)
+
+	ro := om.AsReadOnly()
+
+	if v, ok := ro.Get("d"); !ok || v != 3 {
+		t.Fatalf("get value, wanted: %d, got: %d", 3, v)
+	}
+
+	if !ro.Has("b") || ro.Has("x") {
+		t.Fatalf("has, wanted: (%t, %t), got: (%t, %t)", true, false, ro.Has("b"), ro.Has("x"))
+	}
+
+	om.Set("c", 5)
+
+	if ro.Len() != 3 {
+		t.Fatalf("wanted: %d, got: %d", 3, ro.Len())
+	}
+
+	var keys []string
+	it := ro.Iterator()
+	for k, _, ok := it(); ok; k, _, ok = it() {
+		keys = append(keys, k)
+	}
+
+	if !slices.Equal(keys, []string{"d", "b", "c"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"d", "b", "c"}, keys)
+	}
+
+	typ := reflect.TypeOf((*ReadOnlyMap[string, int])(nil)).Elem()
+
+	var methods []string
+	for i := 0; i < typ.NumMethod(); i++ {
+		methods = append(methods, typ.Method(i).Name)
+	}
+
+	if expected := []string{"Get", "Has", "Iterator", "Len"}; !slices.Equal(methods, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, methods)
+	}
+}
@@ -0,0 +1,74 @@
+package orderedmap
+
+import "iter"
+
+// OrderedSet represents a set which maintains element insertion order.
+//
+// NOTE: This type is NOT thread-safe.
+type OrderedSet[K comparable] struct {
+	om *OrderedMap[K, struct{}]
+}
+
+// NewSet creates a new instance of OrderedSet and returns a pointer to it.
+func NewSet[K comparable]() *OrderedSet[K] {
+	return &OrderedSet[K]{om: New[K, struct{}]()}
+}
+
+// Add adds `key` to the end of a set.
+//
+// Returns:
+//   - true if `key` was added;
+//   - false if `key` is already present in a set, in this case its position is not changed.
+func (s *OrderedSet[K]) Add(key K) bool {
+	return s.om.SetIfAbsent(key, struct{}{})
+}
+
+// Has reports whether `key` is present in a set.
+func (s *OrderedSet[K]) Has(key K) bool {
+	return s.om.Has(key)
+}
+
+// Delete removes `key` from a set.
+//
+// Returns:
+//   - true if `key` was present in a set;
+//   - false otherwise.
+func (s *OrderedSet[K]) Delete(key K) bool {
+	_, ok := s.om.Delete(key)
+	return ok
+}
+
+// Len returns total number of elements in a set.
+func (s *OrderedSet[K]) Len() int {
+	return s.om.Len()
+}
+
+// Values returns a slice of all elements of a set in insertion order.
+//
+// The returned slice is a copy, so it can be safely modified by the caller.
+// For an empty set an empty non-nil slice is returned.
+func (s *OrderedSet[K]) Values() []K {
+	return s.om.Keys()
+}
+
+// Iterator returns a function which can be used to iterate over elements of a set in insertion order.
+//
+// Function next() returns 2 values: an element and a bool flag which indicates
+// if there are any unvisited elements left.
+//
+// NOTE: if a set is modified when iteration is in progress,
+// the result of a subsequent call to next() is undefined.
+func (s *OrderedSet[K]) Iterator() func() (K, bool) {
+	next := s.om.Iterator()
+	return func() (K, bool) {
+		key, _, ok := next()
+		return key, ok
+	}
+}
+
+// All returns an iterator over elements of a set in insertion order.
+//
+// NOTE: if a set is modified when iteration is in progress, the result is undefined.
+func (s *OrderedSet[K]) All() iter.Seq[K] {
+	return s.om.KeysSeq()
+}
@@ -0,0 +1,63 @@
+package orderedmap
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestOrderedSet(t *testing.T) {
+	s := NewSet[string]()
+
+	if s.Len() != 0 || s.Has("missing") {
+		t.Fatalf("new set should be empty")
+	}
+
+	if values := s.Values(); values == nil || len(values) != 0 {
+		t.Fatalf("wanted: empty non-nil slice, got: %#v", values)
+	}
+
+	for _, k := range []string{"d", "b", "c"} {
+		if !s.Add(k) {
+			t.Fatalf("element %q should be added", k)
+		}
+	}
+
+	if s.Add("d") {
+		t.Fatalf("element %q already exists, it should not be added", "d")
+	}
+
+	s.Add("a")
+
+	expected := []string{"d", "b", "c", "a"}
+	if values := s.Values(); !slices.Equal(values, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, values)
+	}
+
+	if s.Len() != 4 || !s.Has("c") {
+		t.Fatalf("set should contain %q", expected)
+	}
+
+	if !s.Delete("b") {
+		t.Fatalf("element %q should be deleted", "b")
+	}
+
+	if s.Delete("b") || s.Has("b") {
+		t.Fatalf("element %q was not deleted as expected", "b")
+	}
+
+	expected = []string{"d", "c", "a"}
+
+	var values []string
+	next := s.Iterator()
+	for k, ok := next(); ok; k, ok = next() {
+		values = append(values, k)
+	}
+
+	if !slices.Equal(values, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, values)
+	}
+
+	if values := slices.Collect(s.All()); !slices.Equal(values, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, values)
+	}
+}
@@ -0,0 +1,90 @@
+package orderedmap
+
+// ShardedOrderedMap is a thread-safe map which distributes keys across a number of independently locked shards.
+//
+// It allows higher write throughput than SyncOrderedMap at the cost of ordering guarantees:
+// keys insertion order is maintained within each shard, but NOT across shards.
+type ShardedOrderedMap[K comparable, V any] struct {
+	shards []*SyncOrderedMap[K, V]
+	hash   func(K) uint64
+}
+
+// NewSharded creates a new instance of ShardedOrderedMap with a given number of shards and returns a pointer to it.
+// A shard for each key is selected using `hash` function.
+//
+// NewSharded panics if `shards` is less than 1.
+func NewSharded[K comparable, V any](shards int, hash func(K) uint64) *ShardedOrderedMap[K, V] {
+	if shards < 1 {
+		panic("orderedmap: number of shards must be positive")
+	}
+
+	sm := &ShardedOrderedMap[K, V]{
+		shards: make([]*SyncOrderedMap[K, V], shards),
+		hash:   hash,
+	}
+
+	for i := range sm.shards {
+		sm.shards[i] = NewSync[K, V]()
+	}
+
+	return sm
+}
+
+// Get retrieves a value corresponding to `key`. See OrderedMap.Get for details.
+func (sm *ShardedOrderedMap[K, V]) Get(key K) (V, bool) {
+	return sm.shard(key).Get(key)
+}
+
+// Set adds a key->value entry to a map. See OrderedMap.Set for details.
+func (sm *ShardedOrderedMap[K, V]) Set(key K, value V) (V, bool) {
+	return sm.shard(key).Set(key, value)
+}
+
+// Delete removes a key->value entry from a map. See OrderedMap.Delete for details.
+func (sm *ShardedOrderedMap[K, V]) Delete(key K) (V, bool) {
+	return sm.shard(key).Delete(key)
+}
+
+// Len returns total number of elements in all shards of a map.
+//
+// NOTE: shards are locked one by one, so the result may be inconsistent if a map is modified concurrently.
+func (sm *ShardedOrderedMap[K, V]) Len() int {
+	n := 0
+	for _, shard := range sm.shards {
+		n += shard.Len()
+	}
+
+	return n
+}
+
+// Shards returns number of shards in a map.
+func (sm *ShardedOrderedMap[K, V]) Shards() int {
+	return len(sm.shards)
+}
+
+// RangeShard calls `fn` for each key->value entry of a shard with a given `index` in keys insertion order.
+// See SyncOrderedMap.Range for details.
+func (sm *ShardedOrderedMap[K, V]) RangeShard(index int, fn func(K, V) bool) {
+	sm.shards[index].Range(fn)
+}
+
+// Range calls `fn` for each key->value entry of a map, visiting shards one by one.
+// Within each shard entries are visited in keys insertion order, there is no ordering across shards.
+// Iteration stops as soon as `fn` returns false. See SyncOrderedMap.Range for details.
+func (sm *ShardedOrderedMap[K, V]) Range(fn func(K, V) bool) {
+	proceed := true
+	for _, shard := range sm.shards {
+		shard.Range(func(k K, v V) bool {
+			proceed = fn(k, v)
+			return proceed
+		})
+
+		if !proceed {
+			return
+		}
+	}
+}
+
+func (sm *ShardedOrderedMap[K, V]) shard(key K) *SyncOrderedMap[K, V] {
+	return sm.shards[sm.hash(key)%uint64(len(sm.shards))]
+}
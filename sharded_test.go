@@ -0,0 +1,124 @@
+package orderedmap
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func intHash(k int) uint64 {
+	return uint64(k)
+}
+
+func TestShardedOrderedMap(t *testing.T) {
+	const (
+		writers = 4
+		readers = 4
+		count   = 1000
+	)
+
+	sm := NewSharded[int, int](8, intHash)
+
+	if sm.Shards() != 8 {
+		t.Fatalf("wanted: %d, got: %d", 8, sm.Shards())
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < count; i++ {
+				key := w*count + i
+				sm.Set(key, key)
+				if i%2 == 1 {
+					sm.Delete(key)
+				}
+			}
+		}(w)
+	}
+
+	for r := 0; r < readers; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < count; i++ {
+				if val, ok := sm.Get(i); ok && val != i {
+					t.Errorf("get value, wanted: %d, got: %d", i, val)
+				}
+				sm.Len()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if sm.Len() != writers*count/2 {
+		t.Fatalf("wanted: %d, got: %d", writers*count/2, sm.Len())
+	}
+
+	for i := 0; i < sm.Shards(); i++ {
+		sm.RangeShard(i, func(k, v int) bool {
+			if k%8 != i || k != v {
+				t.Fatalf("element with key %d should not be in shard %d", k, i)
+			}
+			return true
+		})
+	}
+
+	visited := 0
+	sm.Range(func(int, int) bool {
+		visited++
+		return visited < 10
+	})
+
+	if visited != 10 {
+		t.Fatalf("wanted: %d, got: %d", 10, visited)
+	}
+}
+
+func TestShardedOrderedMapOrder(t *testing.T) {
+	sm := NewSharded[int, string](2, intHash)
+	for _, k := range []int{5, 2, 9, 4, 1, 8} {
+		sm.Set(k, "")
+	}
+
+	sm.Set(5, "updated")
+
+	if val, ok := sm.Get(5); !ok || val != "updated" {
+		t.Fatalf("get value, wanted: %q, got: %q", "updated", val)
+	}
+
+	expected := [][]int{{2, 4, 8}, {5, 9, 1}}
+	for i := range expected {
+		var keys []int
+		sm.RangeShard(i, func(k int, _ string) bool {
+			keys = append(keys, k)
+			return true
+		})
+
+		if !slices.Equal(keys, expected[i]) {
+			t.Fatalf("shard %d, wanted: %d, got: %d", i, expected[i], keys)
+		}
+	}
+}
+
+func BenchmarkConcurrentSet(b *testing.B) {
+	b.Run("Sync", func(b *testing.B) {
+		m := NewSync[int, int]()
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				m.Set(i%1024, i)
+			}
+		})
+	})
+
+	b.Run("Sharded", func(b *testing.B) {
+		m := NewSharded[int, int](16, intHash)
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				m.Set(i%1024, i)
+			}
+		})
+	})
+}
@@ -0,0 +1,54 @@
+package orderedmap
+
+import "slices"
+
+// SortByKey sorts entries of a map by keys using `less` to compare them.
+// The sort is stable. Only keys order is changed, key->value entries are preserved.
+func (om *OrderedMap[K, V]) SortByKey(less func(a, b K) bool) {
+	nodes := om.items.nodes()
+	slices.SortStableFunc(nodes, func(a, b *node[K]) int {
+		return compare(a.value, b.value, less)
+	})
+
+	om.items.relink(nodes)
+}
+
+// SortByValue sorts entries of a map by values using `less` to compare them.
+// The sort is stable. Only keys order is changed, key->value entries are preserved.
+func (om *OrderedMap[K, V]) SortByValue(less func(a, b V) bool) {
+	nodes := om.items.nodes()
+	slices.SortStableFunc(nodes, func(a, b *node[K]) int {
+		return compare(om.data[a.value].value, om.data[b.value].value, less)
+	})
+
+	om.items.relink(nodes)
+}
+
+// Reverse reverses keys order of a map in place.
+func (om *OrderedMap[K, V]) Reverse() {
+	om.items.reverse()
+}
+
+// Rotate cyclically shifts keys order of a map by `k` positions in place.
+// Positive `k` moves the first `k` entries to the end of a map, negative `k` moves the last `-k` entries
+// to the beginning of a map. Rotating by a multiple of Len() doesn't change keys order.
+func (om *OrderedMap[K, V]) Rotate(k int) {
+	n := om.Len()
+	if n == 0 {
+		return
+	}
+
+	om.items.rotate(om.items.at((k%n + n) % n))
+}
+
+// compare converts result of `less` into a three-way comparison result.
+func compare[T any](a, b T, less func(a, b T) bool) int {
+	switch {
+	case less(a, b):
+		return -1
+	case less(b, a):
+		return 1
+	default:
+		return 0
+	}
+}
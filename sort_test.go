@@ -0,0 +1,168 @@
+package orderedmap
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestOrderedMapSortByKey(t *testing.T) {
+	om := New[string, int]()
+	om.Set("d", 3)
+	om.Set("b", 8)
+	om.Set("c", 5)
+	om.Set("a", 9)
+
+	om.SortByKey(func(a, b string) bool { return a < b })
+
+	if keys := om.Keys(); !slices.Equal(keys, []string{"a", "b", "c", "d"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"a", "b", "c", "d"}, keys)
+	}
+
+	if values := om.Values(); !slices.Equal(values, []int{9, 8, 5, 3}) {
+		t.Fatalf("wanted: %d, got: %d", []int{9, 8, 5, 3}, values)
+	}
+
+	om.SortByKey(func(a, b string) bool { return a > b })
+
+	expected := []string{"d", "c", "b", "a"}
+
+	var keys []string
+	next := om.Iterator()
+	for k, _, ok := next(); ok; k, _, ok = next() {
+		keys = append(keys, k)
+	}
+
+	if !slices.Equal(keys, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, keys)
+	}
+
+	if keys := reverseKeys(om); !slices.Equal(keys, reversed(expected)) {
+		t.Fatalf("wanted: %q, got: %q", reversed(expected), keys)
+	}
+
+	if values := om.Values(); !slices.Equal(values, []int{3, 5, 8, 9}) {
+		t.Fatalf("wanted: %d, got: %d", []int{3, 5, 8, 9}, values)
+	}
+}
+
+func TestOrderedMapSortByValue(t *testing.T) {
+	om := New[string, int]()
+	om.Set("d", 3)
+	om.Set("b", 8)
+	om.Set("c", 5)
+	om.Set("a", 9)
+	om.Set("e", 5)
+
+	om.SortByValue(func(a, b int) bool { return a < b })
+
+	if keys := om.Keys(); !slices.Equal(keys, []string{"d", "c", "e", "b", "a"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"d", "c", "e", "b", "a"}, keys)
+	}
+
+	if values := om.Values(); !slices.Equal(values, []int{3, 5, 5, 8, 9}) {
+		t.Fatalf("wanted: %d, got: %d", []int{3, 5, 5, 8, 9}, values)
+	}
+
+	om.SortByValue(func(a, b int) bool { return a > b })
+
+	expected := []string{"a", "b", "c", "e", "d"}
+	if keys := om.Keys(); !slices.Equal(keys, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, keys)
+	}
+
+	if keys := reverseKeys(om); !slices.Equal(keys, reversed(expected)) {
+		t.Fatalf("wanted: %q, got: %q", reversed(expected), keys)
+	}
+
+	if val, _ := om.Get("b"); val != 8 {
+		t.Fatalf("get value, wanted: %d, got: %d", 8, val)
+	}
+}
+
+func TestOrderedMapReverse(t *testing.T) {
+	om := New[string, int]()
+	om.Reverse()
+
+	if om.Len() != 0 {
+		t.Fatalf("wanted: %d, got: %d", 0, om.Len())
+	}
+
+	expected := []string{"d", "b", "c", "a"}
+	for i, k := range expected {
+		om.Set(k, i)
+	}
+
+	om.Reverse()
+
+	if keys := om.Keys(); !slices.Equal(keys, reversed(expected)) {
+		t.Fatalf("wanted: %q, got: %q", reversed(expected), keys)
+	}
+
+	if keys := reverseKeys(om); !slices.Equal(keys, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, keys)
+	}
+
+	if val, _ := om.Get("c"); val != 2 {
+		t.Fatalf("get value, wanted: %d, got: %d", 2, val)
+	}
+
+	om.Reverse()
+
+	if keys := om.Keys(); !slices.Equal(keys, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, keys)
+	}
+
+	om.Set("e", 4)
+
+	if keys := om.Keys(); !slices.Equal(keys, append(expected, "e")) {
+		t.Fatalf("wanted: %q, got: %q", append(expected, "e"), keys)
+	}
+}
+
+func TestOrderedMapRotate(t *testing.T) {
+	keys := []string{"a", "b", "c", "d", "e"}
+
+	tests := []struct {
+		k        int
+		expected []string
+	}{
+		{1, []string{"b", "c", "d", "e", "a"}},
+		{2, []string{"c", "d", "e", "a", "b"}},
+		{0, keys},
+		{5, keys},
+		{7, []string{"c", "d", "e", "a", "b"}},
+		{-1, []string{"e", "a", "b", "c", "d"}},
+		{-7, []string{"d", "e", "a", "b", "c"}},
+		{-10, keys},
+	}
+
+	for _, test := range tests {
+		om := New[string, int]()
+		for i, k := range keys {
+			om.Set(k, i)
+		}
+
+		om.Rotate(test.k)
+
+		if actual := om.Keys(); !slices.Equal(actual, test.expected) {
+			t.Fatalf("rotate by %d, wanted: %q, got: %q", test.k, test.expected, actual)
+		}
+
+		if actual := reverseKeys(om); !slices.Equal(actual, reversed(test.expected)) {
+			t.Fatalf("rotate by %d, wanted: %q, got: %q", test.k, reversed(test.expected), actual)
+		}
+
+		for i, k := range keys {
+			if val, _ := om.Get(k); val != i {
+				t.Fatalf("get value, wanted: %d, got: %d", i, val)
+			}
+		}
+	}
+
+	om := New[string, int]()
+	om.Rotate(3)
+
+	if om.Len() != 0 {
+		t.Fatalf("wanted: %d, got: %d", 0, om.Len())
+	}
+}
@@ -0,0 +1,59 @@
+package orderedmap
+
+import "sync"
+
+// SyncOrderedMap is a thread-safe wrapper around OrderedMap.
+//
+// Read operations can be executed concurrently, write operations are executed exclusively.
+type SyncOrderedMap[K comparable, V any] struct {
+	mu sync.RWMutex
+	om *OrderedMap[K, V]
+}
+
+// NewSync creates a new instance of SyncOrderedMap and returns a pointer to it.
+func NewSync[K comparable, V any]() *SyncOrderedMap[K, V] {
+	return &SyncOrderedMap[K, V]{om: New[K, V]()}
+}
+
+// Get retrieves a value corresponding to `key`. See OrderedMap.Get for details.
+func (sm *SyncOrderedMap[K, V]) Get(key K) (V, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return sm.om.Get(key)
+}
+
+// Set adds a key->value entry to a map. See OrderedMap.Set for details.
+func (sm *SyncOrderedMap[K, V]) Set(key K, value V) (V, bool) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	return sm.om.Set(key, value)
+}
+
+// Delete removes a key->value entry from a map. See OrderedMap.Delete for details.
+func (sm *SyncOrderedMap[K, V]) Delete(key K) (V, bool) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	return sm.om.Delete(key)
+}
+
+// Len returns total number of elements in a map.
+func (sm *SyncOrderedMap[K, V]) Len() int {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return sm.om.Len()
+}
+
+// Range calls `fn` for each key->value entry of a map in keys insertion order.
+// Iteration stops as soon as `fn` returns false.
+//
+// A read lock is held for the whole iteration, so `fn` must not modify a map, otherwise it deadlocks.
+func (sm *SyncOrderedMap[K, V]) Range(fn func(K, V) bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	sm.om.ForEach(fn)
+}
@@ -0,0 +1,63 @@
+package orderedmap
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSyncOrderedMap(t *testing.T) {
+	const (
+		writers = 4
+		readers = 4
+		count   = 1000
+	)
+
+	sm := NewSync[int, int]()
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < count; i++ {
+				key := w*count + i
+				sm.Set(key, key)
+				if i%2 == 1 {
+					sm.Delete(key)
+				}
+			}
+		}(w)
+	}
+
+	for r := 0; r < readers; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < count; i++ {
+				if val, ok := sm.Get(i); ok && val != i {
+					t.Errorf("get value, wanted: %d, got: %d", i, val)
+				}
+				sm.Len()
+				sm.Range(func(k, v int) bool {
+					if k != v {
+						t.Errorf("wanted: %d, got: %d", k, v)
+					}
+					return k < 10
+				})
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if sm.Len() != writers*count/2 {
+		t.Fatalf("wanted: %d, got: %d", writers*count/2, sm.Len())
+	}
+
+	sm.Range(func(k, v int) bool {
+		if k%2 == 1 {
+			t.Fatalf("element with key %d should have been deleted", k)
+		}
+		return true
+	})
+}
@@ -0,0 +1,260 @@
+package orderedmap
+
+// Filter returns a new map which contains only entries for which `pred` returns true.
+// Entries are added to a new map in keys insertion order of the original map,
+// the original map is not modified.
+func (om *OrderedMap[K, V]) Filter(pred func(K, V) bool) *OrderedMap[K, V] {
+	res := New[K, V]()
+	for curr := om.items.head; curr != nil; curr = curr.next {
+		if val := om.data[curr.value].value; pred(curr.value, val) {
+			res.Set(curr.value, val)
+		}
+	}
+
+	return res
+}
+
+// Partition splits a map into two new maps: `match` contains entries for which `pred` returns true,
+// `rest` contains all other entries. Entries in both maps are kept in keys insertion order of the original map,
+// the original map is not modified.
+func (om *OrderedMap[K, V]) Partition(pred func(K, V) bool) (match, rest *OrderedMap[K, V]) {
+	match, rest = New[K, V](), New[K, V]()
+	for curr := om.items.head; curr != nil; curr = curr.next {
+		if val := om.data[curr.value].value; pred(curr.value, val) {
+			match.Set(curr.value, val)
+		} else {
+			rest.Set(curr.value, val)
+		}
+	}
+
+	return match, rest
+}
+
+// Slice returns a new map which contains entries at positions within [start, end) range in keys insertion order.
+// Bounds are clamped to [0, Len()] range, if `start` is not less than `end` an empty map is returned.
+// The original map is not modified.
+//
+// NOTE: this operation takes linear time, because it requires walking the keys list.
+func (om *OrderedMap[K, V]) Slice(start, end int) *OrderedMap[K, V] {
+	start = max(start, 0)
+	end = min(end, om.Len())
+
+	res := New[K, V]()
+	for curr := om.items.at(start); curr != nil && start < end; curr, start = curr.next, start+1 {
+		res.Set(curr.value, om.data[curr.value].value)
+	}
+
+	return res
+}
+
+// Head returns a new map which contains the first `n` entries in keys insertion order.
+// If `n` is greater than Len(), all entries are returned. The original map is not modified.
+func (om *OrderedMap[K, V]) Head(n int) *OrderedMap[K, V] {
+	return om.Slice(0, n)
+}
+
+// Tail returns a new map which contains the last `n` entries in keys insertion order.
+// If `n` is greater than Len(), all entries are returned. The original map is not modified.
+func (om *OrderedMap[K, V]) Tail(n int) *OrderedMap[K, V] {
+	return om.Slice(om.Len()-max(n, 0), om.Len())
+}
+
+// Chunk splits entries of a map into chunks of at most `size` entries each in keys insertion order.
+// All chunks except the last one contain exactly `size` entries.
+// If `size` is not positive nil is returned.
+func (om *OrderedMap[K, V]) Chunk(size int) [][]Pair[K, V] {
+	if size <= 0 {
+		return nil
+	}
+
+	chunks := make([][]Pair[K, V], 0, (om.Len()+size-1)/size)
+	for curr := om.items.head; curr != nil; curr = curr.next {
+		if len(chunks) == 0 || len(chunks[len(chunks)-1]) == size {
+			chunks = append(chunks, make([]Pair[K, V], 0, size))
+		}
+
+		last := &chunks[len(chunks)-1]
+		*last = append(*last, Pair[K, V]{curr.value, om.data[curr.value].value})
+	}
+
+	return chunks
+}
+
+// Intersection returns a new map which contains only entries with given `keys`.
+// Entries are added to a new map in keys insertion order of the original map,
+// the original map is not modified.
+func (om *OrderedMap[K, V]) Intersection(keys ...K) *OrderedMap[K, V] {
+	set := keySet(keys)
+	return om.Filter(func(k K, _ V) bool {
+		_, ok := set[k]
+		return ok
+	})
+}
+
+// Difference returns a new map which contains only entries with keys other than given `keys`.
+// Entries are added to a new map in keys insertion order of the original map,
+// the original map is not modified.
+func (om *OrderedMap[K, V]) Difference(keys ...K) *OrderedMap[K, V] {
+	set := keySet(keys)
+	return om.Filter(func(k K, _ V) bool {
+		_, ok := set[k]
+		return !ok
+	})
+}
+
+// Count returns number of entries for which `pred` returns true.
+func (om *OrderedMap[K, V]) Count(pred func(K, V) bool) int {
+	count := 0
+	for curr := om.items.head; curr != nil; curr = curr.next {
+		if pred(curr.value, om.data[curr.value].value) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Find returns the first entry in keys insertion order for which `pred` returns true.
+//
+// Returns:
+//   - (key, value, true) if a matching entry was found;
+//   - (<zero>, <zero>, false) otherwise, where <zero> represents a default value for types K and V.
+func (om *OrderedMap[K, V]) Find(pred func(K, V) bool) (K, V, bool) {
+	for curr := om.items.head; curr != nil; curr = curr.next {
+		if pred(curr.value, om.data[curr.value].value) {
+			return om.entry(curr)
+		}
+	}
+
+	return om.entry(nil)
+}
+
+// Any reports whether `pred` returns true for at least one entry.
+// Entries are visited in keys insertion order, iteration stops at the first matching entry.
+// For an empty map Any returns false.
+func (om *OrderedMap[K, V]) Any(pred func(K, V) bool) bool {
+	for curr := om.items.head; curr != nil; curr = curr.next {
+		if pred(curr.value, om.data[curr.value].value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Every reports whether `pred` returns true for every entry.
+// Entries are visited in keys insertion order, iteration stops at the first non-matching entry.
+// For an empty map Every returns true.
+//
+// NOTE: this method is not named All, because All returns an iterator over map entries.
+func (om *OrderedMap[K, V]) Every(pred func(K, V) bool) bool {
+	return !om.Any(func(k K, v V) bool { return !pred(k, v) })
+}
+
+// MaxBy returns an entry with the largest value using `less` to compare values.
+// If several entries have the largest value, the one with the earliest key in keys insertion order is returned.
+//
+// Returns:
+//   - (key, value, true) if a map is not empty;
+//   - (<zero>, <zero>, false) otherwise, where <zero> represents a default value for types K and V.
+func (om *OrderedMap[K, V]) MaxBy(less func(a, b V) bool) (K, V, bool) {
+	return om.extreme(less)
+}
+
+// MinBy returns an entry with the smallest value using `less` to compare values.
+// If several entries have the smallest value, the one with the earliest key in keys insertion order is returned.
+//
+// Returns:
+//   - (key, value, true) if a map is not empty;
+//   - (<zero>, <zero>, false) otherwise, where <zero> represents a default value for types K and V.
+func (om *OrderedMap[K, V]) MinBy(less func(a, b V) bool) (K, V, bool) {
+	return om.extreme(func(best, v V) bool { return less(v, best) })
+}
+
+// extreme returns the first entry with a value for which `better` never reports that another value is better.
+func (om *OrderedMap[K, V]) extreme(better func(best, v V) bool) (K, V, bool) {
+	best := om.items.head
+	if best == nil {
+		return om.entry(nil)
+	}
+
+	for curr := best.next; curr != nil; curr = curr.next {
+		if better(om.data[best.value].value, om.data[curr.value].value) {
+			best = curr
+		}
+	}
+
+	return om.entry(best)
+}
+
+// MapValues returns a new map with the same keys in the same order as `om`,
+// where each value is replaced by the result of `fn` applied to the original entry.
+func MapValues[K comparable, V, R any](om *OrderedMap[K, V], fn func(K, V) R) *OrderedMap[K, R] {
+	res := New[K, R]()
+	for curr := om.items.head; curr != nil; curr = curr.next {
+		res.Set(curr.value, fn(curr.value, om.data[curr.value].value))
+	}
+
+	return res
+}
+
+// FlatMap returns a new map built from the pairs returned by `fn` applied to each entry of `om` in keys insertion order.
+// `fn` may return any number of pairs, including none. Pairs are added to the result using Set,
+// so if a key is returned more than once, the last value is kept, but the key retains the position of its first occurrence.
+func FlatMap[K comparable, V any, K2 comparable, V2 any](om *OrderedMap[K, V], fn func(K, V) []Pair[K2, V2]) *OrderedMap[K2, V2] {
+	res := New[K2, V2]()
+	for curr := om.items.head; curr != nil; curr = curr.next {
+		for _, p := range fn(curr.value, om.data[curr.value].value) {
+			res.Set(p.Key, p.Value)
+		}
+	}
+
+	return res
+}
+
+// Reduce folds entries of a map in keys insertion order: `fn` is called for each entry
+// with the result of the previous call (or `init` for the first entry), the result of the last call is returned.
+// If a map is empty, `init` is returned.
+func Reduce[K comparable, V, A any](om *OrderedMap[K, V], init A, fn func(acc A, k K, v V) A) A {
+	acc := init
+	for curr := om.items.head; curr != nil; curr = curr.next {
+		acc = fn(acc, curr.value, om.data[curr.value].value)
+	}
+
+	return acc
+}
+
+// Union returns a new map which contains all entries of `a` followed by entries of `b` with keys not present in `a`.
+// For keys present in both maps, a value from `b` is used, while the key keeps its position from `a`.
+// Neither `a` nor `b` is modified.
+func Union[K comparable, V any](a, b *OrderedMap[K, V]) *OrderedMap[K, V] {
+	res := New[K, V]()
+	res.Merge(a)
+	res.Merge(b)
+
+	return res
+}
+
+// GroupBy returns a new map which groups values of `om` by keys produced by `keyFn`.
+// Groups are added to a new map in the order in which their keys are first produced,
+// values within each group are kept in keys insertion order of `om`.
+func GroupBy[K comparable, V any, G comparable](om *OrderedMap[K, V], keyFn func(K, V) G) *OrderedMap[G, []V] {
+	res := New[G, []V]()
+	for curr := om.items.head; curr != nil; curr = curr.next {
+		val := om.data[curr.value].value
+		res.Update(keyFn(curr.value, val), func(group []V, _ bool) []V {
+			return append(group, val)
+		})
+	}
+
+	return res
+}
+
+func keySet[K comparable](keys []K) map[K]struct{} {
+	set := make(map[K]struct{}, len(keys))
+	for _, key := range keys {
+		set[key] = struct{}{}
+	}
+
+	return set
+}
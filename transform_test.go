@@ -0,0 +1,579 @@
+package orderedmap
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestOrderedMapFilter(t *testing.T) {
+	om := New[string, int]()
+	om.Set("d", 3)
+	om.Set("b", 8)
+	om.Set("c", 5)
+	om.Set("a", 9)
+
+	tests := []struct {
+		pred     func(string, int) bool
+		expected []string
+	}{
+		{func(_ string, v int) bool { return v%2 == 1 }, []string{"d", "c", "a"}},
+		{func(string, int) bool { return false }, []string{}},
+		{func(string, int) bool { return true }, []string{"d", "b", "c", "a"}},
+	}
+
+	for _, test := range tests {
+		res := om.Filter(test.pred)
+
+		if keys := res.Keys(); !slices.Equal(keys, test.expected) {
+			t.Fatalf("wanted: %q, got: %q", test.expected, keys)
+		}
+
+		for k, v := range res.All() {
+			if val, _ := om.Get(k); val != v {
+				t.Fatalf("get value, wanted: %d, got: %d", val, v)
+			}
+		}
+	}
+
+	if keys := om.Keys(); !slices.Equal(keys, []string{"d", "b", "c", "a"}) {
+		t.Fatalf("filter should not modify original map, got: %q", keys)
+	}
+}
+
+func TestOrderedMapPartition(t *testing.T) {
+	om := New[string, int]()
+	om.Set("d", 3)
+	om.Set("b", 8)
+	om.Set("c", 5)
+	om.Set("a", 9)
+
+	tests := []struct {
+		pred        func(string, int) bool
+		match, rest []string
+	}{
+		{func(_ string, v int) bool { return v%2 == 1 }, []string{"d", "c", "a"}, []string{"b"}},
+		{func(string, int) bool { return true }, []string{"d", "b", "c", "a"}, []string{}},
+		{func(string, int) bool { return false }, []string{}, []string{"d", "b", "c", "a"}},
+	}
+
+	for _, test := range tests {
+		match, rest := om.Partition(test.pred)
+
+		if keys := match.Keys(); !slices.Equal(keys, test.match) {
+			t.Fatalf("wanted: %q, got: %q", test.match, keys)
+		}
+
+		if keys := rest.Keys(); !slices.Equal(keys, test.rest) {
+			t.Fatalf("wanted: %q, got: %q", test.rest, keys)
+		}
+
+		for k, v := range Union(match, rest).All() {
+			if val, _ := om.Get(k); val != v {
+				t.Fatalf("get value, wanted: %d, got: %d", val, v)
+			}
+		}
+	}
+
+	if om.Len() != 4 {
+		t.Fatalf("partition should not modify original map, got: %v", om)
+	}
+}
+
+func TestOrderedMapSlice(t *testing.T) {
+	om := New[string, int]()
+	for i, k := range []string{"a", "b", "c", "d", "e"} {
+		om.Set(k, i)
+	}
+
+	tests := []struct {
+		start, end int
+		expected   []string
+	}{
+		{1, 3, []string{"b", "c"}},
+		{2, 2, []string{}},
+		{0, 5, []string{"a", "b", "c", "d", "e"}},
+		{-2, 2, []string{"a", "b"}},
+		{3, 10, []string{"d", "e"}},
+		{4, 1, []string{}},
+		{-3, -1, []string{}},
+		{5, 7, []string{}},
+	}
+
+	for _, test := range tests {
+		res := om.Slice(test.start, test.end)
+
+		if keys := res.Keys(); !slices.Equal(keys, test.expected) {
+			t.Fatalf("slice [%d, %d), wanted: %q, got: %q", test.start, test.end, test.expected, keys)
+		}
+
+		for k, v := range res.All() {
+			if val, _ := om.Get(k); val != v {
+				t.Fatalf("get value, wanted: %d, got: %d", val, v)
+			}
+		}
+	}
+
+	if om.Len() != 5 {
+		t.Fatalf("slice should not modify original map, got: %v", om)
+	}
+}
+
+func TestOrderedMapHeadAndTail(t *testing.T) {
+	om := New[string, int]()
+	for i, k := range []string{"a", "b", "c", "d", "e"} {
+		om.Set(k, i)
+	}
+
+	tests := []struct {
+		n          int
+		head, tail []string
+	}{
+		{2, []string{"a", "b"}, []string{"d", "e"}},
+		{5, []string{"a", "b", "c", "d", "e"}, []string{"a", "b", "c", "d", "e"}},
+		{0, []string{}, []string{}},
+		{10, []string{"a", "b", "c", "d", "e"}, []string{"a", "b", "c", "d", "e"}},
+		{-1, []string{}, []string{}},
+	}
+
+	for _, test := range tests {
+		if keys := om.Head(test.n).Keys(); !slices.Equal(keys, test.head) {
+			t.Fatalf("head %d, wanted: %q, got: %q", test.n, test.head, keys)
+		}
+
+		if keys := om.Tail(test.n).Keys(); !slices.Equal(keys, test.tail) {
+			t.Fatalf("tail %d, wanted: %q, got: %q", test.n, test.tail, keys)
+		}
+	}
+
+	if values := om.Tail(2).Values(); !slices.Equal(values, []int{3, 4}) {
+		t.Fatalf("wanted: %d, got: %d", []int{3, 4}, values)
+	}
+
+	if om.Len() != 5 {
+		t.Fatalf("head and tail should not modify original map, got: %v", om)
+	}
+}
+
+func TestOrderedMapChunk(t *testing.T) {
+	om := New[string, int]()
+	for i, k := range []string{"a", "b", "c", "d", "e", "f"} {
+		om.Set(k, i)
+	}
+
+	tests := []struct {
+		size     int
+		expected [][]string
+	}{
+		{3, [][]string{{"a", "b", "c"}, {"d", "e", "f"}}},
+		{4, [][]string{{"a", "b", "c", "d"}, {"e", "f"}}},
+		{1, [][]string{{"a"}, {"b"}, {"c"}, {"d"}, {"e"}, {"f"}}},
+		{10, [][]string{{"a", "b", "c", "d", "e", "f"}}},
+	}
+
+	for _, test := range tests {
+		chunks := om.Chunk(test.size)
+
+		if len(chunks) != len(test.expected) {
+			t.Fatalf("chunk size %d, wanted: %d chunks, got: %d", test.size, len(test.expected), len(chunks))
+		}
+
+		for i, chunk := range chunks {
+			keys := make([]string, 0, len(chunk))
+			for _, p := range chunk {
+				if val, _ := om.Get(p.Key); val != p.Value {
+					t.Fatalf("get value, wanted: %d, got: %d", val, p.Value)
+				}
+				keys = append(keys, p.Key)
+			}
+
+			if !slices.Equal(keys, test.expected[i]) {
+				t.Fatalf("chunk size %d, wanted: %q, got: %q", test.size, test.expected[i], keys)
+			}
+		}
+	}
+
+	for _, size := range []int{0, -1} {
+		if chunks := om.Chunk(size); chunks != nil {
+			t.Fatalf("chunk size %d is invalid, got: %v", size, chunks)
+		}
+	}
+
+	if chunks := New[string, int]().Chunk(2); len(chunks) != 0 {
+		t.Fatalf("wanted: no chunks, got: %v", chunks)
+	}
+}
+
+func TestOrderedMapIntersection(t *testing.T) {
+	om := New[string, int]()
+	om.Set("d", 3)
+	om.Set("b", 8)
+	om.Set("c", 5)
+	om.Set("a", 9)
+
+	tests := []struct {
+		keys     []string
+		expected []string
+	}{
+		{[]string{"a", "missing", "b"}, []string{"b", "a"}},
+		{[]string{"x", "y"}, []string{}},
+		{nil, []string{}},
+		{[]string{"a", "b", "c", "d"}, []string{"d", "b", "c", "a"}},
+	}
+
+	for _, test := range tests {
+		res := om.Intersection(test.keys...)
+
+		if keys := res.Keys(); !slices.Equal(keys, test.expected) {
+			t.Fatalf("wanted: %q, got: %q", test.expected, keys)
+		}
+
+		for k, v := range res.All() {
+			if val, _ := om.Get(k); val != v {
+				t.Fatalf("get value, wanted: %d, got: %d", val, v)
+			}
+		}
+	}
+
+	if om.Len() != 4 {
+		t.Fatalf("intersection should not modify original map, got: %v", om)
+	}
+}
+
+func TestOrderedMapDifference(t *testing.T) {
+	om := New[string, int]()
+	om.Set("d", 3)
+	om.Set("b", 8)
+	om.Set("c", 5)
+	om.Set("a", 9)
+
+	tests := []struct {
+		keys     []string
+		expected []string
+	}{
+		{[]string{"a", "missing", "b"}, []string{"d", "c"}},
+		{nil, []string{"d", "b", "c", "a"}},
+		{[]string{"a", "b", "c", "d"}, []string{}},
+	}
+
+	for _, test := range tests {
+		res := om.Difference(test.keys...)
+
+		if keys := res.Keys(); !slices.Equal(keys, test.expected) {
+			t.Fatalf("wanted: %q, got: %q", test.expected, keys)
+		}
+
+		for k, v := range res.All() {
+			if val, _ := om.Get(k); val != v {
+				t.Fatalf("get value, wanted: %d, got: %d", val, v)
+			}
+		}
+	}
+
+	if om.Len() != 4 {
+		t.Fatalf("difference should not modify original map, got: %v", om)
+	}
+}
+
+func TestMapValues(t *testing.T) {
+	om := New[string, int]()
+	om.Set("d", 3)
+	om.Set("b", 8)
+	om.Set("c", 5)
+
+	res := MapValues(om, func(k string, v int) string {
+		return fmt.Sprintf("%s=%d", k, v)
+	})
+
+	if keys := res.Keys(); !slices.Equal(keys, om.Keys()) {
+		t.Fatalf("wanted: %q, got: %q", om.Keys(), keys)
+	}
+
+	expected := []string{"d=3", "b=8", "c=5"}
+	if values := res.Values(); !slices.Equal(values, expected) {
+		t.Fatalf("wanted: %q, got: %q", expected, values)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	om := New[string, int]()
+
+	if sum := Reduce(om, 10, func(acc int, _ string, v int) int { return acc + v }); sum != 10 {
+		t.Fatalf("wanted: %d, got: %d", 10, sum)
+	}
+
+	om.Set("d", 3)
+	om.Set("b", 8)
+	om.Set("c", 5)
+
+	if sum := Reduce(om, 0, func(acc int, _ string, v int) int { return acc + v }); sum != 16 {
+		t.Fatalf("wanted: %d, got: %d", 16, sum)
+	}
+
+	if s := Reduce(om, "", func(acc string, k string, _ int) string { return acc + k }); s != "dbc" {
+		t.Fatalf("wanted: %q, got: %q", "dbc", s)
+	}
+}
+
+func TestUnion(t *testing.T) {
+	build := func(pairs ...Pair[string, int]) *OrderedMap[string, int] {
+		om := New[string, int]()
+		om.SetMany(pairs...)
+		return om
+	}
+
+	tests := []struct {
+		a, b     *OrderedMap[string, int]
+		expected string
+	}{
+		{
+			build(Pair[string, int]{"d", 3}, Pair[string, int]{"b", 8}),
+			build(Pair[string, int]{"c", 5}, Pair[string, int]{"a", 9}),
+			"OrderedMap[d:3, b:8, c:5, a:9]",
+		},
+		{
+			build(Pair[string, int]{"d", 3}, Pair[string, int]{"b", 8}),
+			build(Pair[string, int]{"c", 5}, Pair[string, int]{"d", 9}),
+			"OrderedMap[d:9, b:8, c:5]",
+		},
+		{
+			build(),
+			build(Pair[string, int]{"c", 5}, Pair[string, int]{"a", 9}),
+			"OrderedMap[c:5, a:9]",
+		},
+		{
+			build(Pair[string, int]{"d", 3}, Pair[string, int]{"b", 8}),
+			build(),
+			"OrderedMap[d:3, b:8]",
+		},
+	}
+
+	for _, test := range tests {
+		a, b := test.a.String(), test.b.String()
+
+		if res := Union(test.a, test.b).String(); res != test.expected {
+			t.Fatalf("wanted: %s, got: %s", test.expected, res)
+		}
+
+		if test.a.String() != a || test.b.String() != b {
+			t.Fatalf("union should not modify its operands")
+		}
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	{
+		om := New[string, int]()
+		for i, k := range []string{"d", "b", "c", "a", "e"} {
+			om.Set(k, i+1)
+		}
+
+		res := GroupBy(om, func(_ string, v int) bool { return v%2 == 0 })
+
+		if keys := res.Keys(); !slices.Equal(keys, []bool{false, true}) {
+			t.Fatalf("wanted: %v, got: %v", []bool{false, true}, keys)
+		}
+
+		if odd, _ := res.Get(false); !slices.Equal(odd, []int{1, 3, 5}) {
+			t.Fatalf("wanted: %d, got: %d", []int{1, 3, 5}, odd)
+		}
+
+		if even, _ := res.Get(true); !slices.Equal(even, []int{2, 4}) {
+			t.Fatalf("wanted: %d, got: %d", []int{2, 4}, even)
+		}
+	}
+
+	{
+		om := New[int, string]()
+		for i, v := range []string{"banana", "apple", "blueberry", "cherry", "avocado"} {
+			om.Set(i, v)
+		}
+
+		res := GroupBy(om, func(_ int, v string) byte { return v[0] })
+
+		const expected = "OrderedMap[98:[banana blueberry], 97:[apple avocado], 99:[cherry]]"
+		if s := res.String(); s != expected {
+			t.Fatalf("wanted: %s, got: %s", expected, s)
+		}
+	}
+
+	if res := GroupBy(New[string, int](), func(k string, _ int) string { return k }); res.Len() != 0 {
+		t.Fatalf("wanted: %d, got: %d", 0, res.Len())
+	}
+}
+
+func TestOrderedMapCount(t *testing.T) {
+	om := New[string, int]()
+
+	if n := om.Count(func(string, int) bool { return true }); n != 0 {
+		t.Fatalf("wanted: %d, got: %d", 0, n)
+	}
+
+	om.Set("d", 3)
+	om.Set("b", 8)
+	om.Set("c", 5)
+	om.Set("a", 9)
+
+	tests := []struct {
+		pred     func(string, int) bool
+		expected int
+	}{
+		{func(_ string, v int) bool { return v%2 == 1 }, 3},
+		{func(string, int) bool { return false }, 0},
+		{func(string, int) bool { return true }, 4},
+	}
+
+	for _, test := range tests {
+		if n := om.Count(test.pred); n != test.expected {
+			t.Fatalf("wanted: %d, got: %d", test.expected, n)
+		}
+	}
+}
+
+func TestOrderedMapFind(t *testing.T) {
+	om := New[string, int]()
+
+	if _, _, ok := om.Find(func(string, int) bool { return true }); ok {
+		t.Fatalf("it should not be possible to find anything in empty map")
+	}
+
+	om.Set("d", 3)
+	om.Set("b", 8)
+	om.Set("c", 6)
+	om.Set("a", 9)
+
+	if k, v, ok := om.Find(func(_ string, v int) bool { return v == 6 }); !ok || k != "c" || v != 6 {
+		t.Fatalf("find, wanted: (%q, %d), got: (%q, %d)", "c", 6, k, v)
+	}
+
+	if k, v, ok := om.Find(func(_ string, v int) bool { return v%2 == 0 }); !ok || k != "b" || v != 8 {
+		t.Fatalf("find, wanted: (%q, %d), got: (%q, %d)", "b", 8, k, v)
+	}
+
+	if k, v, ok := om.Find(func(_ string, v int) bool { return v > 10 }); ok || k != "" || v != 0 {
+		t.Fatalf("nothing should be found, got: (%q, %d)", k, v)
+	}
+}
+
+func TestOrderedMapAnyAndEvery(t *testing.T) {
+	om := New[string, int]()
+
+	if om.Any(func(string, int) bool { return true }) {
+		t.Fatalf("any should return false for empty map")
+	}
+
+	if !om.Every(func(string, int) bool { return false }) {
+		t.Fatalf("every should return true for empty map")
+	}
+
+	om.Set("d", 3)
+	om.Set("b", 8)
+	om.Set("c", 5)
+	om.Set("a", 9)
+
+	var visited []string
+	if !om.Any(func(k string, v int) bool {
+		visited = append(visited, k)
+		return v%2 == 0
+	}) {
+		t.Fatalf("any should return true when some entry matches")
+	}
+
+	if !slices.Equal(visited, []string{"d", "b"}) {
+		t.Fatalf("any should stop at the first match, wanted: %q, got: %q", []string{"d", "b"}, visited)
+	}
+
+	visited = visited[:0]
+	if om.Every(func(k string, v int) bool {
+		visited = append(visited, k)
+		return v%2 == 1
+	}) {
+		t.Fatalf("every should return false when some entry doesn't match")
+	}
+
+	if !slices.Equal(visited, []string{"d", "b"}) {
+		t.Fatalf("every should stop at the first mismatch, wanted: %q, got: %q", []string{"d", "b"}, visited)
+	}
+
+	if om.Any(func(_ string, v int) bool { return v > 10 }) {
+		t.Fatalf("any should return false when no entry matches")
+	}
+
+	if !om.Every(func(_ string, v int) bool { return v < 10 }) {
+		t.Fatalf("every should return true when all entries match")
+	}
+}
+
+func TestOrderedMapMaxByAndMinBy(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+
+	om := New[string, int]()
+
+	if _, _, ok := om.MaxBy(less); ok {
+		t.Fatalf("it should not be possible to get max element of empty map")
+	}
+
+	if _, _, ok := om.MinBy(less); ok {
+		t.Fatalf("it should not be possible to get min element of empty map")
+	}
+
+	om.Set("d", 3)
+	om.Set("b", 8)
+	om.Set("c", 1)
+	om.Set("a", 5)
+
+	if k, v, ok := om.MaxBy(less); !ok || k != "b" || v != 8 {
+		t.Fatalf("max, wanted: (%q, %d), got: (%q, %d)", "b", 8, k, v)
+	}
+
+	if k, v, ok := om.MinBy(less); !ok || k != "c" || v != 1 {
+		t.Fatalf("min, wanted: (%q, %d), got: (%q, %d)", "c", 1, k, v)
+	}
+
+	om.Set("e", 8)
+	om.Set("f", 1)
+
+	if k, v, ok := om.MaxBy(less); !ok || k != "b" || v != 8 {
+		t.Fatalf("max, wanted: (%q, %d), got: (%q, %d)", "b", 8, k, v)
+	}
+
+	if k, v, ok := om.MinBy(less); !ok || k != "c" || v != 1 {
+		t.Fatalf("min, wanted: (%q, %d), got: (%q, %d)", "c", 1, k, v)
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	om := New[string, string]()
+	om.Set("x", "a,b")
+	om.Set("y", "")
+	om.Set("z", "c,a")
+
+	res := FlatMap(om, func(k, v string) []Pair[string, string] {
+		if v == "" {
+			return nil
+		}
+
+		var pairs []Pair[string, string]
+		for _, s := range strings.Split(v, ",") {
+			pairs = append(pairs, Pair[string, string]{s, k})
+		}
+
+		return pairs
+	})
+
+	if keys := res.Keys(); !slices.Equal(keys, []string{"a", "b", "c"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"a", "b", "c"}, keys)
+	}
+
+	if values := res.Values(); !slices.Equal(values, []string{"z", "x", "z"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"z", "x", "z"}, values)
+	}
+
+	if res := FlatMap(New[string, string](), func(k, v string) []Pair[int, int] {
+		t.Fatalf("fn should not be called for an empty map")
+		return nil
+	}); res.Len() != 0 {
+		t.Fatalf("wanted: %d, got: %d", 0, res.Len())
+	}
+}
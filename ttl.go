@@ -0,0 +1,109 @@
+package orderedmap
+
+import "time"
+
+// TTLOrderedMap is a wrapper around OrderedMap which supports entries with limited lifetime.
+//
+// Expired entries are removed lazily, i.e. when they are accessed, so no background goroutine is required.
+// Expired entries are treated as absent by all methods.
+//
+// NOTE: This type is NOT thread-safe.
+type TTLOrderedMap[K comparable, V any] struct {
+	om  *OrderedMap[K, ttlEntry[V]]
+	now func() time.Time
+}
+
+type ttlEntry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+// NewTTL creates a new instance of TTLOrderedMap and returns a pointer to it.
+func NewTTL[K comparable, V any]() *TTLOrderedMap[K, V] {
+	return NewTTLWithClock[K, V](time.Now)
+}
+
+// NewTTLWithClock creates a new instance of TTLOrderedMap which uses `now` to get current time
+// and returns a pointer to it. This is mostly useful for testing.
+func NewTTLWithClock[K comparable, V any](now func() time.Time) *TTLOrderedMap[K, V] {
+	return &TTLOrderedMap[K, V]{
+		om:  New[K, ttlEntry[V]](),
+		now: now,
+	}
+}
+
+// Get retrieves a value corresponding to `key`.
+//
+// Returns:
+//   - (value, true) if corresponding key->value pair is present in a map and is not expired;
+//   - (<zero>, false) is returned otherwise, where <zero> represents a default value for type V.
+func (tm *TTLOrderedMap[K, V]) Get(key K) (V, bool) {
+	tm.expire(key)
+
+	entry, ok := tm.om.Get(key)
+	return entry.value, ok
+}
+
+// Set adds a key->value entry which never expires to a map. See SetWithTTL for details.
+func (tm *TTLOrderedMap[K, V]) Set(key K, value V) (V, bool) {
+	return tm.set(key, ttlEntry[V]{value: value})
+}
+
+// SetWithTTL adds a key->value entry which expires after `ttl` to a map.
+//
+// If `key` is already present in a map and is not expired, corresponding entry is updated with a new value
+// and a new expiration time, insertion order of keys is not changed. An expired `key` is added to the end of a map.
+//
+// Parameters:
+//   - `key` - map entry key.
+//   - `value` - map entry value.
+//   - `ttl` - lifetime of the entry.
+//
+// Returns:
+//   - (old, true) if `key` already existed in a map and was not expired, where `old` is a previous value of the entry;
+//   - (<zero>, false) otherwise, where <zero> represents a default value for type V.
+func (tm *TTLOrderedMap[K, V]) SetWithTTL(key K, value V, ttl time.Duration) (V, bool) {
+	return tm.set(key, ttlEntry[V]{value: value, expires: tm.now().Add(ttl)})
+}
+
+// Delete removes a key->value entry from a map.
+//
+// Returns:
+//   - (value, true) if key->value entry was present in a map and was not expired;
+//   - (<zero>, false) is returned otherwise where <zero> represents a default value for type V.
+func (tm *TTLOrderedMap[K, V]) Delete(key K) (V, bool) {
+	tm.expire(key)
+
+	entry, ok := tm.om.Delete(key)
+	return entry.value, ok
+}
+
+// Len returns total number of not expired elements in a map.
+//
+// NOTE: this operation takes linear time, because it removes all expired entries.
+func (tm *TTLOrderedMap[K, V]) Len() int {
+	now := tm.now()
+	tm.om.DeleteFunc(func(_ K, entry ttlEntry[V]) bool {
+		return entry.expired(now)
+	})
+
+	return tm.om.Len()
+}
+
+func (tm *TTLOrderedMap[K, V]) set(key K, entry ttlEntry[V]) (V, bool) {
+	tm.expire(key)
+
+	old, ok := tm.om.Set(key, entry)
+	return old.value, ok
+}
+
+// expire removes an entry with a given `key` if it is expired.
+func (tm *TTLOrderedMap[K, V]) expire(key K) {
+	if entry, ok := tm.om.Get(key); ok && entry.expired(tm.now()) {
+		tm.om.Delete(key)
+	}
+}
+
+func (e ttlEntry[V]) expired(now time.Time) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires)
+}
@@ -0,0 +1,90 @@
+package orderedmap
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTTLOrderedMap(t *testing.T) {
+	now := time.Date(2022, time.March, 31, 0, 0, 0, 0, time.UTC)
+	tm := NewTTLWithClock[string, int](func() time.Time { return now })
+
+	tm.SetWithTTL("a", 0, time.Minute)
+	tm.SetWithTTL("b", 1, time.Hour)
+	tm.Set("c", 2)
+	tm.SetWithTTL("d", 3, time.Second)
+
+	if tm.Len() != 4 {
+		t.Fatalf("wanted: %d, got: %d", 4, tm.Len())
+	}
+
+	now = now.Add(time.Second)
+
+	if val, ok := tm.Get("d"); ok || val != 0 {
+		t.Fatalf("element with key %q should be expired", "d")
+	}
+
+	if val, ok := tm.Get("a"); !ok || val != 0 {
+		t.Fatalf("get value, wanted: %d, got: %d", 0, val)
+	}
+
+	if val, ok := tm.SetWithTTL("a", 10, time.Hour); !ok || val != 0 {
+		t.Fatalf("set value, wanted: %d, got: %d", 0, val)
+	}
+
+	now = now.Add(time.Minute)
+
+	if val, ok := tm.Get("a"); !ok || val != 10 {
+		t.Fatalf("ttl should be updated, wanted: %d, got: %d", 10, val)
+	}
+
+	now = now.Add(time.Hour)
+
+	if tm.Len() != 1 {
+		t.Fatalf("wanted: %d, got: %d", 1, tm.Len())
+	}
+
+	for _, k := range []string{"a", "b"} {
+		if _, ok := tm.Get(k); ok {
+			t.Fatalf("element with key %q should be expired", k)
+		}
+	}
+
+	if val, ok := tm.Get("c"); !ok || val != 2 {
+		t.Fatalf("get value, wanted: %d, got: %d", 2, val)
+	}
+}
+
+func TestTTLOrderedMapExpiredKeys(t *testing.T) {
+	now := time.Date(2022, time.March, 31, 0, 0, 0, 0, time.UTC)
+	tm := NewTTLWithClock[string, int](func() time.Time { return now })
+
+	tm.SetWithTTL("a", 0, time.Second)
+	tm.Set("b", 1)
+
+	now = now.Add(time.Second)
+
+	if val, ok := tm.Set("a", 10); ok || val != 0 {
+		t.Fatalf("element with key %q should be expired", "a")
+	}
+
+	if keys := tm.om.Keys(); !slices.Equal(keys, []string{"b", "a"}) {
+		t.Fatalf("expired key should be added to the end, wanted: %q, got: %q", []string{"b", "a"}, keys)
+	}
+
+	tm.SetWithTTL("b", 1, time.Second)
+	now = now.Add(time.Second)
+
+	if val, ok := tm.Delete("b"); ok || val != 0 {
+		t.Fatalf("element with key %q should be expired", "b")
+	}
+
+	if val, ok := tm.Delete("a"); !ok || val != 10 {
+		t.Fatalf("delete value, wanted: %d, got: %d", 10, val)
+	}
+
+	if tm.Len() != 0 {
+		t.Fatalf("wanted: %d, got: %d", 0, tm.Len())
+	}
+}
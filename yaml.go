@@ -0,0 +1,64 @@
+package orderedmap
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalYAML implements yaml.Marshaler interface.
+//
+// A map is encoded as a YAML mapping with keys written in keys insertion order.
+func (om *OrderedMap[K, V]) MarshalYAML() (any, error) {
+	res := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+	for curr := om.first(); curr != nil; curr = curr.next {
+		var key, val yaml.Node
+
+		if err := key.Encode(curr.value); err != nil {
+			return nil, err
+		}
+
+		if err := val.Encode(om.data[curr.value].value); err != nil {
+			return nil, err
+		}
+
+		res.Content = append(res.Content, &key, &val)
+	}
+
+	return res, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface.
+//
+// Entries are added to a map in the order in which keys appear in a YAML mapping.
+// If a key appears in a mapping more than once, the last value is kept,
+// but the key retains the position of its first occurrence (the same way Set works).
+func (om *OrderedMap[K, V]) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode && value.Tag == "!!null" {
+		return nil
+	}
+
+	if value.Kind != yaml.MappingNode {
+		return fmt.Errorf("orderedmap: cannot unmarshal YAML node of kind %d at line %d into an ordered map, mapping expected",
+			value.Kind, value.Line)
+	}
+
+	om.lazyInit()
+
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		var key K
+		if err := value.Content[i].Decode(&key); err != nil {
+			return err
+		}
+
+		var val V
+		if err := value.Content[i+1].Decode(&val); err != nil {
+			return err
+		}
+
+		om.Set(key, val)
+	}
+
+	return nil
+}
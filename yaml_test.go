@@ -0,0 +1,91 @@
+package orderedmap
+
+import (
+	"slices"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestOrderedMapYAML(t *testing.T) {
+	const input = `server:
+    port: 8080
+    host: localhost
+database:
+    user: admin
+    name: test
+empty: {}
+`
+
+	om := New[string, *OrderedMap[string, any]]()
+	if err := yaml.Unmarshal([]byte(input), om); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if keys := om.Keys(); !slices.Equal(keys, []string{"server", "database", "empty"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"server", "database", "empty"}, keys)
+	}
+
+	server, _ := om.Get("server")
+	if s := server.String(); s != "OrderedMap[port:8080, host:localhost]" {
+		t.Fatalf("wanted: %s, got: %s", "OrderedMap[port:8080, host:localhost]", s)
+	}
+
+	database, _ := om.Get("database")
+	if keys := database.Keys(); !slices.Equal(keys, []string{"user", "name"}) {
+		t.Fatalf("wanted: %q, got: %q", []string{"user", "name"}, keys)
+	}
+
+	data, err := yaml.Marshal(om)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(data) != input {
+		t.Fatalf("wanted: %s, got: %s", input, data)
+	}
+}
+
+func TestOrderedMapYAMLErrors(t *testing.T) {
+	for _, input := range []string{"- 1\n- 2\n", "text\n", "a: text\n"} {
+		om := New[string, int]()
+		if err := yaml.Unmarshal([]byte(input), om); err == nil {
+			t.Fatalf("unmarshaling %q should fail", input)
+		}
+	}
+
+	data, err := yaml.Marshal(New[string, int]())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(data) != "{}\n" {
+		t.Fatalf("wanted: %q, got: %q", "{}\n", data)
+	}
+}
+
+func TestOrderedMapYAMLZeroValue(t *testing.T) {
+	s := struct {
+		M OrderedMap[string, int] `yaml:"m"`
+	}{}
+
+	data, err := yaml.Marshal(&s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(data) != "m: {}\n" {
+		t.Fatalf("wanted: %q, got: %q", "m: {}\n", data)
+	}
+
+	var om OrderedMap[string, int]
+
+	res, err := om.MarshalYAML()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if node := res.(*yaml.Node); node.Kind != yaml.MappingNode || len(node.Content) != 0 {
+		t.Fatalf("zero value map should be encoded as an empty mapping, got: %#v", node)
+	}
+}